@@ -0,0 +1,103 @@
+// Package cache provides in-memory caches which are safe for concurrent use
+package cache
+
+import "time"
+
+// Cache maps keys to values and evicts entries by its own policy
+type Cache[K comparable, V any] interface {
+	// Get returns the value of the key and counts a hit
+	// a zero value and a false if the key is not cached, counting a miss
+	Get(key K) (V, bool)
+
+	// Set caches the value of the key, evicting entries if needed
+	Set(key K, value V)
+
+	// Delete removes the key from the cache without counting an eviction
+	// false if the key is not cached
+	Delete(key K) bool
+
+	// Contains checks if the key is cached
+	// without counting a hit or a miss nor changing the eviction order
+	Contains(key K) bool
+
+	// Len returns the total number of cached keys
+	Len() int
+
+	// Clear removes all keys from the cache without counting evictions
+	Clear()
+
+	// Stats returns the hit, miss and eviction counts
+	Stats() Stats
+}
+
+// Stats counts the outcomes of the cache operations
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRatio returns the ratio of the lookups which were hits,
+// zero if there was no lookup
+func (s Stats) HitRatio() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(lookups)
+}
+
+type settings[K comparable, V any] struct {
+	onEvict func(key K, value V)
+	now     func() time.Time
+}
+
+func newSettings[K comparable, V any](options []Option[K, V]) *settings[K, V] {
+	// Default settings
+	settings := &settings[K, V]{
+		now: time.Now,
+	}
+	for _, option := range options {
+		option.Apply(settings)
+	}
+	return settings
+}
+
+type Option[K comparable, V any] interface {
+	Apply(*settings[K, V])
+}
+
+// WithOnEvict calls the function for every entry evicted by the cache
+// policy. The function is called after the cache is unlocked, so it may
+// use the cache. Deleted and cleared entries are not evictions.
+func WithOnEvict[K comparable, V any](onEvict func(key K, value V)) Option[K, V] {
+	return withOnEvict[K, V](onEvict)
+}
+
+type withOnEvict[K comparable, V any] func(key K, value V)
+
+func (w withOnEvict[K, V]) Apply(settings *settings[K, V]) {
+	settings.onEvict = w
+}
+
+// eviction is an evicted entry waiting for the callback
+type eviction[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// notify calls the eviction callback, it must be called without the lock
+func (s *settings[K, V]) notify(evictions []eviction[K, V]) {
+	if s.onEvict == nil {
+		return
+	}
+	for _, evicted := range evictions {
+		s.onEvict(evicted.key, evicted.value)
+	}
+}
+
+func mustBePositive(capacity int) {
+	if capacity < 1 {
+		panic("cache: capacity must be positive")
+	}
+}
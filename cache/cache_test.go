@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runCacheTests checks the behaviors shared by every cache,
+// the cache must have room for a few hundred keys
+func runCacheTests(t *testing.T, newCacheFunc func(options ...Option[string, int]) Cache[string, int]) {
+	testCases := []struct {
+		name string
+		test func(t *testing.T, newCacheFunc func(options ...Option[string, int]) Cache[string, int])
+	}{
+		{"testCache_GetSet", testCache_GetSet},
+		{"testCache_Delete", testCache_Delete},
+		{"testCache_Clear", testCache_Clear},
+		{"testCache_Stats", testCache_Stats},
+		{"testCache_Concurrent", testCache_Concurrent},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.test(t, newCacheFunc)
+		})
+	}
+}
+
+func testCache_GetSet(t *testing.T, newCacheFunc func(options ...Option[string, int]) Cache[string, int]) {
+	cache := newCacheFunc()
+	actual, ok := cache.Get("a")
+	require.False(t, ok, "Get on an empty cache should return false")
+	require.Equal(t, 0, actual, "Get on an empty cache should return zero value for int")
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("a", 10)
+	actual, ok = cache.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 10, actual, "Set should replace the value")
+	require.True(t, cache.Contains("b"))
+	require.False(t, cache.Contains("c"))
+	require.Equal(t, 2, cache.Len())
+}
+
+func testCache_Delete(t *testing.T, newCacheFunc func(options ...Option[string, int]) Cache[string, int]) {
+	evicted := 0
+	cache := newCacheFunc(WithOnEvict(func(string, int) { evicted++ }))
+	cache.Set("a", 1)
+
+	require.True(t, cache.Delete("a"), "Delete should return true for a cached key")
+	require.False(t, cache.Delete("a"), "Delete should return false once deleted")
+	require.False(t, cache.Contains("a"))
+	require.Equal(t, 0, cache.Len())
+	require.Zero(t, evicted, "Delete should not call the eviction callback")
+	require.Zero(t, cache.Stats().Evictions, "Delete should not count an eviction")
+}
+
+func testCache_Clear(t *testing.T, newCacheFunc func(options ...Option[string, int]) Cache[string, int]) {
+	cache := newCacheFunc()
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Clear()
+	require.Equal(t, 0, cache.Len())
+	require.False(t, cache.Contains("a"))
+
+	cache.Set("c", 3)
+	actual, ok := cache.Get("c")
+	require.True(t, ok, "cache should be usable after Clear")
+	require.Equal(t, 3, actual)
+}
+
+func testCache_Stats(t *testing.T, newCacheFunc func(options ...Option[string, int]) Cache[string, int]) {
+	cache := newCacheFunc()
+	require.Equal(t, Stats{}, cache.Stats())
+	require.Zero(t, cache.Stats().HitRatio(), "HitRatio without lookups should be zero")
+
+	cache.Set("a", 1)
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("a")
+	cache.Get("b")
+	cache.Contains("b")
+
+	stats := cache.Stats()
+	require.Equal(t, Stats{Hits: 3, Misses: 1}, stats, "Contains should not count")
+	require.Equal(t, 0.75, stats.HitRatio())
+}
+
+func testCache_Concurrent(t *testing.T, newCacheFunc func(options ...Option[string, int]) Cache[string, int]) {
+	cache := newCacheFunc()
+
+	// wrong counts the values a goroutine did not get back
+	wrong := make([]int, 8)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := strconv.Itoa(id*100 + j)
+				cache.Set(key, j)
+				if actual, ok := cache.Get(key); !ok || actual != j {
+					wrong[id]++
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, make([]int, 8), wrong)
+
+	stats := cache.Stats()
+	require.Equal(t, uint64(800), stats.Hits)
+	require.Zero(t, stats.Misses)
+}
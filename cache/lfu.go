@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// LFU evicts the least frequently used key when full,
+// the least recently used one among the keys used as often.
+// Get and Set count as a use of the key. Get, Set and evictions take
+// constant time, Delete may scan the use counts.
+type LFU[K comparable, V any] struct {
+	lock     sync.Mutex
+	settings *settings[K, V]
+	capacity int
+	stats    Stats
+
+	entries map[K]*lfuEntry[V]
+	// buckets keeps the keys used the same number of times
+	// from the least to the most recently used
+	buckets map[int]*collection.OrderedMap[K, struct{}]
+	// minUses is the use count of the least frequently used keys
+	minUses int
+}
+
+type lfuEntry[V any] struct {
+	value V
+	uses  int
+}
+
+// NewLFU creates an LFU cache holding at most capacity keys
+func NewLFU[K comparable, V any](capacity int, options ...Option[K, V]) Cache[K, V] {
+	mustBePositive(capacity)
+	return &LFU[K, V]{
+		settings: newSettings(options),
+		capacity: capacity,
+		entries:  map[K]*lfuEntry[V]{},
+		buckets:  map[int]*collection.OrderedMap[K, struct{}]{},
+	}
+}
+
+// Get returns the value of the key and counts a use
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.use(key, entry)
+	return entry.value, true
+}
+
+// Set caches the value of the key and counts a use,
+// evicting the least frequently used key if full
+func (c *LFU[K, V]) Set(key K, value V) {
+	c.settings.notify(c.set(key, value))
+}
+
+func (c *LFU[K, V]) set(key K, value V) []eviction[K, V] {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		entry.value = value
+		c.use(key, entry)
+		return nil
+	}
+
+	var evictions []eviction[K, V]
+	if len(c.entries) >= c.capacity {
+		evictedKey, _, _ := c.buckets[c.minUses].PopFront()
+		evicted := c.remove(evictedKey, c.minUses)
+		c.stats.Evictions++
+		evictions = append(evictions, eviction[K, V]{evictedKey, evicted.value})
+	}
+
+	c.entries[key] = &lfuEntry[V]{value: value, uses: 1}
+	c.bucket(1).Set(key, struct{}{})
+	c.minUses = 1
+	return evictions
+}
+
+// use moves the key to the bucket of the next use count
+func (c *LFU[K, V]) use(key K, entry *lfuEntry[V]) {
+	bucket := c.buckets[entry.uses]
+	bucket.Delete(key)
+	if bucket.Len() == 0 {
+		delete(c.buckets, entry.uses)
+		if c.minUses == entry.uses {
+			c.minUses++
+		}
+	}
+
+	entry.uses++
+	c.bucket(entry.uses).Set(key, struct{}{})
+}
+
+// bucket returns the bucket of the use count, creating it if needed
+func (c *LFU[K, V]) bucket(uses int) *collection.OrderedMap[K, struct{}] {
+	bucket, ok := c.buckets[uses]
+	if !ok {
+		bucket = collection.NewOrderedMap[K, struct{}]()
+		c.buckets[uses] = bucket
+	}
+	return bucket
+}
+
+// remove forgets the key already taken out of the bucket of its use count
+// The minimum use count is left as is, the next Set resets it.
+func (c *LFU[K, V]) remove(key K, uses int) *lfuEntry[V] {
+	entry := c.entries[key]
+	delete(c.entries, key)
+	if c.buckets[uses].Len() == 0 {
+		delete(c.buckets, uses)
+	}
+	return entry
+}
+
+// Delete removes the key from the cache
+func (c *LFU[K, V]) Delete(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	c.buckets[entry.uses].Delete(key)
+	c.remove(key, entry.uses)
+	if len(c.entries) > 0 && c.buckets[c.minUses] == nil {
+		// the deleted key was the last one used the fewest times
+		c.minUses = c.findMinUses()
+	}
+	return true
+}
+
+// findMinUses scans the buckets for the lowest use count
+// It only runs after deleting, eviction never needs it.
+func (c *LFU[K, V]) findMinUses() int {
+	minUses := 0
+	for uses := range c.buckets {
+		if minUses == 0 || uses < minUses {
+			minUses = uses
+		}
+	}
+	return minUses
+}
+
+// Contains checks if the key is cached
+func (c *LFU[K, V]) Contains(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Len returns the total number of cached keys
+func (c *LFU[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.entries)
+}
+
+// Clear removes all keys from the cache
+func (c *LFU[K, V]) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	clear(c.entries)
+	clear(c.buckets)
+	c.minUses = 0
+}
+
+// Stats returns the hit, miss and eviction counts
+func (c *LFU[K, V]) Stats() Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
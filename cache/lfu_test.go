@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLFU(t *testing.T) {
+	runCacheTests(t, func(options ...Option[string, int]) Cache[string, int] {
+		return NewLFU(1000, options...)
+	})
+
+	t.Run("the least frequently used key should be evicted", func(t *testing.T) {
+		evicted := []string{}
+		cache := NewLFU(3, WithOnEvict(func(key string, _ int) {
+			evicted = append(evicted, key)
+		}))
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Set("c", 3)
+		cache.Get("a")
+		cache.Get("a")
+		cache.Set("c", 30)
+
+		cache.Set("d", 4)
+		require.Equal(t, []string{"b"}, evicted)
+
+		// d is now the only key used once
+		cache.Set("e", 5)
+		require.Equal(t, []string{"b", "d"}, evicted)
+
+		// c and e tie so the least recently used goes
+		cache.Get("e")
+		cache.Set("f", 6)
+		require.Equal(t, []string{"b", "d", "c"}, evicted)
+		require.True(t, cache.Contains("a"))
+		require.Equal(t, 3, cache.Len())
+	})
+
+	t.Run("deleting the least frequently used key should keep evicting", func(t *testing.T) {
+		evicted := []string{}
+		cache := NewLFU(3, WithOnEvict(func(key string, _ int) {
+			evicted = append(evicted, key)
+		}))
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Set("c", 3)
+		cache.Get("b")
+		cache.Get("c")
+		cache.Get("c")
+
+		require.True(t, cache.Delete("a"))
+		cache.Set("d", 4)
+		cache.Set("e", 5)
+		require.Equal(t, []string{"d"}, evicted)
+
+		require.True(t, cache.Delete("e"))
+		require.True(t, cache.Delete("b"))
+		cache.Set("f", 6)
+		cache.Set("g", 7)
+		cache.Set("h", 8)
+		require.Equal(t, []string{"d", "f"}, evicted)
+		require.True(t, cache.Contains("c"))
+	})
+}
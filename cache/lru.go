@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// LRU evicts the least recently used key when full.
+// Get and Set mark a key as the most recently used.
+type LRU[K comparable, V any] struct {
+	lock     sync.Mutex
+	settings *settings[K, V]
+	capacity int
+	stats    Stats
+	// entries keeps the keys from the least to the most recently used
+	entries *collection.OrderedMap[K, V]
+}
+
+// NewLRU creates an LRU cache holding at most capacity keys
+func NewLRU[K comparable, V any](capacity int, options ...Option[K, V]) Cache[K, V] {
+	mustBePositive(capacity)
+	return &LRU[K, V]{
+		settings: newSettings(options),
+		capacity: capacity,
+		entries:  collection.NewOrderedMap[K, V](),
+	}
+}
+
+// Get returns the value of the key and marks it as the most recently used
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	value, ok := c.entries.Get(key)
+	if !ok {
+		c.stats.Misses++
+		return value, false
+	}
+	c.stats.Hits++
+	c.entries.MoveToBack(key)
+	return value, true
+}
+
+// Set caches the value of the key and marks it as the most recently used,
+// evicting the least recently used key if full
+func (c *LRU[K, V]) Set(key K, value V) {
+	c.settings.notify(c.set(key, value))
+}
+
+func (c *LRU[K, V]) set(key K, value V) []eviction[K, V] {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.entries.Contains(key) {
+		c.entries.Set(key, value)
+		c.entries.MoveToBack(key)
+		return nil
+	}
+
+	var evictions []eviction[K, V]
+	if c.entries.Len() >= c.capacity {
+		evictedKey, evictedValue, _ := c.entries.PopFront()
+		c.stats.Evictions++
+		evictions = append(evictions, eviction[K, V]{evictedKey, evictedValue})
+	}
+	c.entries.Set(key, value)
+	return evictions
+}
+
+// Delete removes the key from the cache
+func (c *LRU[K, V]) Delete(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.entries.Delete(key)
+}
+
+// Contains checks if the key is cached
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.entries.Contains(key)
+}
+
+// Len returns the total number of cached keys
+func (c *LRU[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.entries.Len()
+}
+
+// Clear removes all keys from the cache
+func (c *LRU[K, V]) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries.Clear()
+}
+
+// Stats returns the hit, miss and eviction counts
+func (c *LRU[K, V]) Stats() Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRU(t *testing.T) {
+	runCacheTests(t, func(options ...Option[string, int]) Cache[string, int] {
+		return NewLRU(1000, options...)
+	})
+
+	t.Run("the least recently used key should be evicted", func(t *testing.T) {
+		evicted := map[string]int{}
+		cache := NewLRU(3, WithOnEvict(func(key string, value int) {
+			evicted[key] = value
+		}))
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Set("c", 3)
+
+		cache.Get("a")
+		cache.Set("b", 20)
+		cache.Set("d", 4)
+		require.Equal(t, map[string]int{"c": 3}, evicted, "Get and Set should mark the key as used")
+
+		cache.Contains("a")
+		cache.Set("e", 5)
+		require.Equal(t, map[string]int{"c": 3, "a": 1}, evicted, "Contains should not mark the key as used")
+
+		require.Equal(t, 3, cache.Len())
+		require.Equal(t, uint64(2), cache.Stats().Evictions)
+	})
+
+	t.Run("the eviction callback may use the cache", func(t *testing.T) {
+		var cache Cache[string, int]
+		cache = NewLRU(1, WithOnEvict(func(key string, value int) {
+			require.False(t, cache.Contains(key))
+		}))
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		require.True(t, cache.Contains("b"))
+	})
+
+	t.Run("non-positive capacity should panic", func(t *testing.T) {
+		require.PanicsWithValue(t, "cache: capacity must be positive", func() {
+			NewLRU[string, int](0)
+		})
+	})
+}
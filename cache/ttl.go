@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// TTL expires every key a fixed duration after it was last set.
+// Expired keys are evicted by the next operation on the cache,
+// so they are never returned nor counted.
+type TTL[K comparable, V any] struct {
+	lock     sync.Mutex
+	settings *settings[K, V]
+	ttl      time.Duration
+	stats    Stats
+	// entries keeps the keys from the first to the last to expire,
+	// as every key lives for the same duration
+	entries *collection.OrderedMap[K, ttlEntry[V]]
+}
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// NewTTL creates a cache whose keys expire the duration after being set
+func NewTTL[K comparable, V any](ttl time.Duration, options ...Option[K, V]) Cache[K, V] {
+	if ttl <= 0 {
+		panic("cache: ttl must be positive")
+	}
+	return &TTL[K, V]{
+		settings: newSettings(options),
+		ttl:      ttl,
+		entries:  collection.NewOrderedMap[K, ttlEntry[V]](),
+	}
+}
+
+// Get returns the value of the key unless it has expired
+func (c *TTL[K, V]) Get(key K) (V, bool) {
+	var entry ttlEntry[V]
+	var ok bool
+	c.expireThen(func() {
+		entry, ok = c.entries.Get(key)
+		if ok {
+			c.stats.Hits++
+		} else {
+			c.stats.Misses++
+		}
+	})
+	return entry.value, ok
+}
+
+// Set caches the value of the key until the duration has passed
+func (c *TTL[K, V]) Set(key K, value V) {
+	c.expireThen(func() {
+		c.entries.Set(key, ttlEntry[V]{value: value, expires: c.settings.now().Add(c.ttl)})
+		c.entries.MoveToBack(key)
+	})
+}
+
+// Delete removes the key from the cache
+func (c *TTL[K, V]) Delete(key K) bool {
+	var deleted bool
+	c.expireThen(func() {
+		deleted = c.entries.Delete(key)
+	})
+	return deleted
+}
+
+// Contains checks if the key is cached and has not expired
+func (c *TTL[K, V]) Contains(key K) bool {
+	var ok bool
+	c.expireThen(func() {
+		ok = c.entries.Contains(key)
+	})
+	return ok
+}
+
+// Len returns the total number of keys which have not expired
+func (c *TTL[K, V]) Len() int {
+	var size int
+	c.expireThen(func() {
+		size = c.entries.Len()
+	})
+	return size
+}
+
+// expireThen evicts the expired keys then runs the operation with the lock
+// held, and calls the eviction callback once unlocked
+func (c *TTL[K, V]) expireThen(operation func()) {
+	c.settings.notify(c.expireThenLocked(operation))
+}
+
+func (c *TTL[K, V]) expireThenLocked(operation func()) []eviction[K, V] {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	evictions := c.expire()
+	operation()
+	return evictions
+}
+
+// expire evicts the expired keys, it must be called with the lock held
+func (c *TTL[K, V]) expire() []eviction[K, V] {
+	var evictions []eviction[K, V]
+	now := c.settings.now()
+	for {
+		key, entry, ok := c.entries.Front()
+		if !ok || now.Before(entry.expires) {
+			return evictions
+		}
+		c.entries.PopFront()
+		c.stats.Evictions++
+		evictions = append(evictions, eviction[K, V]{key, entry.value})
+	}
+}
+
+// Clear removes all keys from the cache
+func (c *TTL[K, V]) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries.Clear()
+}
+
+// Stats returns the hit, miss and eviction counts
+func (c *TTL[K, V]) Stats() Stats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTL(t *testing.T) {
+	runCacheTests(t, func(options ...Option[string, int]) Cache[string, int] {
+		return NewTTL(time.Hour, options...)
+	})
+
+	// newCache creates a cache whose clock only moves with the returned function
+	newCache := func(options ...Option[string, int]) (*TTL[string, int], func(time.Duration)) {
+		cache := NewTTL(time.Minute, options...).(*TTL[string, int])
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		cache.settings.now = func() time.Time { return now }
+		return cache, func(d time.Duration) { now = now.Add(d) }
+	}
+
+	t.Run("keys should expire after the duration", func(t *testing.T) {
+		evicted := []string{}
+		cache, advance := newCache(WithOnEvict(func(key string, _ int) {
+			evicted = append(evicted, key)
+		}))
+		cache.Set("a", 1)
+		advance(30 * time.Second)
+		cache.Set("b", 2)
+
+		advance(29 * time.Second)
+		require.True(t, cache.Contains("a"), "a key should live for the whole duration")
+
+		advance(time.Second)
+		_, ok := cache.Get("a")
+		require.False(t, ok, "an expired key should not be returned")
+		require.True(t, cache.Contains("b"))
+		require.Equal(t, 1, cache.Len())
+		require.Equal(t, []string{"a"}, evicted)
+
+		advance(time.Hour)
+		require.Equal(t, 0, cache.Len(), "Len should not count the expired keys")
+		require.Equal(t, []string{"a", "b"}, evicted)
+		require.Equal(t, Stats{Misses: 1, Evictions: 2}, cache.Stats())
+	})
+
+	t.Run("setting a key should renew it", func(t *testing.T) {
+		cache, advance := newCache()
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		advance(50 * time.Second)
+		cache.Set("a", 10)
+
+		advance(20 * time.Second)
+		require.False(t, cache.Contains("b"))
+		actual, ok := cache.Get("a")
+		require.True(t, ok, "a renewed key should not expire")
+		require.Equal(t, 10, actual)
+	})
+
+	t.Run("eviction callback should be called once unlocked", func(t *testing.T) {
+		var cache *TTL[string, int]
+		reentered := false
+		cache, advance := newCache(WithOnEvict(func(key string, _ int) {
+			if key == "panic" {
+				panic("callback panic")
+			}
+			reentered = !cache.Contains(key)
+		}))
+		cache.Set("a", 1)
+		advance(time.Minute)
+		cache.Len()
+		require.True(t, reentered, "the callback should be able to use the cache")
+
+		cache.Set("panic", 1)
+		advance(time.Minute)
+		require.PanicsWithValue(t, "callback panic", func() { cache.Len() })
+		cache.Set("b", 2)
+		require.True(t, cache.Contains("b"), "the cache should be usable after a callback panic")
+	})
+
+	t.Run("non-positive ttl should panic", func(t *testing.T) {
+		require.PanicsWithValue(t, "cache: ttl must be positive", func() {
+			NewTTL[string, int](0)
+		})
+	})
+}
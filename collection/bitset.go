@@ -0,0 +1,217 @@
+package collection
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// BitSet is a set of non-negative integers stored as one bit each.
+// It grows as larger bits are set. The zero value is an empty set.
+//
+// Using a negative bit panics.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates a new bit set with room for the bits below size
+// without growing
+func NewBitSet(size int) *BitSet {
+	return &BitSet{words: make([]uint64, 0, wordCount(size))}
+}
+
+// wordCount returns the number of words holding the bits below size
+func wordCount(size int) int {
+	return (size + 63) / 64
+}
+
+// bitIndex returns the word holding the bit and the mask of the bit
+func bitIndex(i int) (int, uint64) {
+	if i < 0 {
+		panic(fmt.Sprintf("collection: negative bit %d", i))
+	}
+	return i / 64, 1 << (i % 64)
+}
+
+// grow makes room for the word
+func (b *BitSet) grow(word int) {
+	if word >= len(b.words) {
+		b.words = append(b.words, make([]uint64, word+1-len(b.words))...)
+	}
+}
+
+// Set sets the bit
+func (b *BitSet) Set(i int) {
+	word, mask := bitIndex(i)
+	b.grow(word)
+	b.words[word] |= mask
+}
+
+// Clear clears the bit
+func (b *BitSet) Clear(i int) {
+	word, mask := bitIndex(i)
+	if word < len(b.words) {
+		b.words[word] &^= mask
+	}
+}
+
+// Test checks if the bit is set
+func (b *BitSet) Test(i int) bool {
+	word, mask := bitIndex(i)
+	return word < len(b.words) && b.words[word]&mask != 0
+}
+
+// Flip sets the bit if it is clear and clears it otherwise
+func (b *BitSet) Flip(i int) {
+	word, mask := bitIndex(i)
+	b.grow(word)
+	b.words[word] ^= mask
+}
+
+// Count returns the number of bits set
+func (b *BitSet) Count() int {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Empty returns true if no bit is set
+func (b *BitSet) Empty() bool {
+	for _, word := range b.words {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ClearAll clears all the bits
+func (b *BitSet) ClearAll() {
+	b.words = b.words[:0]
+}
+
+// NextSet returns the first bit set from the bit included
+// -1 and a false if there is none
+func (b *BitSet) NextSet(i int) (int, bool) {
+	word, _ := bitIndex(i)
+	if word >= len(b.words) {
+		return -1, false
+	}
+
+	// skip the bits below i in the first word
+	w := b.words[word] >> (i % 64)
+	if w != 0 {
+		return i + bits.TrailingZeros64(w), true
+	}
+	for word++; word < len(b.words); word++ {
+		if b.words[word] != 0 {
+			return word*64 + bits.TrailingZeros64(b.words[word]), true
+		}
+	}
+	return -1, false
+}
+
+// Equal checks if both sets have the same bits set
+func (b *BitSet) Equal(other *BitSet) bool {
+	for i := range max(len(b.words), len(other.words)) {
+		if b.word(i) != other.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// word returns the word at the index, zero beyond the words
+func (b *BitSet) word(i int) uint64 {
+	if i < len(b.words) {
+		return b.words[i]
+	}
+	return 0
+}
+
+// Set algebra, each operation returns a new bit set
+
+// And returns the bits set in both sets
+func (b *BitSet) And(other *BitSet) *BitSet {
+	return b.combine(other, min(len(b.words), len(other.words)), func(x, y uint64) uint64 {
+		return x & y
+	})
+}
+
+// Or returns the bits set in either set
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	return b.combine(other, max(len(b.words), len(other.words)), func(x, y uint64) uint64 {
+		return x | y
+	})
+}
+
+// Xor returns the bits set in exactly one of the sets
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	return b.combine(other, max(len(b.words), len(other.words)), func(x, y uint64) uint64 {
+		return x ^ y
+	})
+}
+
+// AndNot returns the bits set in this set but not in the other
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	return b.combine(other, len(b.words), func(x, y uint64) uint64 {
+		return x &^ y
+	})
+}
+
+// combine applies the operation to the first n words of both sets
+func (b *BitSet) combine(other *BitSet, n int, operation func(x, y uint64) uint64) *BitSet {
+	result := &BitSet{words: make([]uint64, n)}
+	for i := range result.words {
+		result.words[i] = operation(b.word(i), other.word(i))
+	}
+	return result
+}
+
+// All returns an iterator over the bits set in ascending order
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the set
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// MarshalBinary encodes the set as little endian 64-bit words,
+// without the trailing zero words
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+
+	data := make([]byte, 0, n*8)
+	for _, word := range b.words[:n] {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a set encoded by MarshalBinary,
+// replacing the bits of the set
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return fmt.Errorf("collection: invalid BitSet encoding of %d bytes", len(data))
+	}
+
+	b.words = make([]uint64, len(data)/8)
+	for i := range b.words {
+		b.words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package collection
+
+import "errors"
+
+// ErrDequeFull is returned when inserting into a full bounded deque
+// which rejects new elements
+var ErrDequeFull = errors.New("deque is full")
+
+// OverflowPolicy decides what a bounded deque does when an element
+// is added while the deque is full
+type OverflowPolicy int
+
+const (
+	// OverflowReject rejects the new element and keeps the deque unchanged
+	OverflowReject OverflowPolicy = iota
+
+	// OverflowDropOldest evicts the element at the opposite end of
+	// the insertion, e.g. the front element when pushing to the back,
+	// to make room for the new element
+	OverflowDropOldest
+
+	// OverflowDropNewest evicts the element at the end of the insertion,
+	// e.g. the back element when pushing to the back,
+	// to make room for the new element
+	OverflowDropNewest
+)
+
+// BoundedDeque is a deque which holds at most a fixed number of elements.
+// Push, PushFront, PushBack, Enqueue, ExtendFront, ExtendBack and Insert
+// apply the overflow policy when the deque is full. Rejected elements are
+// silently discarded, use OfferFront and OfferBack to find out if an element
+// has been added.
+// Insert treats the front element as the oldest and returns ErrDequeFull
+// when rejecting an element.
+type BoundedDeque[X any] interface {
+	Deque[X]
+
+	// OfferFront adds an element to the front of the deque
+	// false if the element is rejected because the deque is full
+	OfferFront(x X) bool
+
+	// OfferBack adds an element to the back of the deque
+	// false if the element is rejected because the deque is full
+	OfferBack(x X) bool
+
+	// Capacity returns the maximum number of elements in the deque
+	Capacity() int
+
+	// Full returns if the deque has reached its capacity
+	Full() bool
+}
+
+// boundedDeque limits the size of the underlying deque
+type boundedDeque[X any] struct {
+	Deque[X]
+	capacity int
+	policy   OverflowPolicy
+}
+
+// NewBoundedDeque creates a new bounded deque backed by a circular buffer
+func NewBoundedDeque[X comparable](capacity int, policy OverflowPolicy) BoundedDeque[X] {
+	return NewBoundedRingDeque[X](capacity, policy)
+}
+
+// NewBoundedLinkedDeque creates a new bounded deque backed by a linked list
+func NewBoundedLinkedDeque[X comparable](capacity int, policy OverflowPolicy) BoundedDeque[X] {
+	return NewBoundedDequeOf(NewLinkedDeque[X](), capacity, policy)
+}
+
+// NewBoundedSliceDeque creates a new bounded deque backed by a slice
+func NewBoundedSliceDeque[X comparable](capacity int, policy OverflowPolicy) BoundedDeque[X] {
+	return NewBoundedDequeOf(NewSliceDeque[X](), capacity, policy)
+}
+
+// NewBoundedRingDeque creates a new bounded deque backed by a circular buffer
+func NewBoundedRingDeque[X comparable](capacity int, policy OverflowPolicy) BoundedDeque[X] {
+	return NewBoundedDequeOf(NewRingDeque[X](), capacity, policy)
+}
+
+// NewBoundedDequeOf limits the number of elements in the deque.
+// The deque should no longer be used directly.
+func NewBoundedDequeOf[X any](deque Deque[X], capacity int, policy OverflowPolicy) BoundedDeque[X] {
+	if capacity < 1 {
+		panic("collection: bounded deque capacity must be positive")
+	}
+	return &boundedDeque[X]{
+		Deque:    deque,
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// equality returns the equality function of the underlying deque
+func (b *boundedDeque[X]) equality() func(a, b X) bool {
+	return equalityOf(b.Deque)
+}
+
+// Push adds an element to the top of the deque
+func (b *boundedDeque[X]) Push(x X) {
+	b.OfferBack(x)
+}
+
+// Enqueue adds an element to the end of the deque
+func (b *boundedDeque[X]) Enqueue(x X) {
+	b.OfferBack(x)
+}
+
+// PushFront adds an element to the front of the deque
+func (b *boundedDeque[X]) PushFront(x X) {
+	b.OfferFront(x)
+}
+
+// PushBack adds an element to the back of the deque
+func (b *boundedDeque[X]) PushBack(x X) {
+	b.OfferBack(x)
+}
+
+// OfferFront adds an element to the front of the deque
+// false if the element is rejected because the deque is full
+func (b *boundedDeque[X]) OfferFront(x X) bool {
+	if b.Full() {
+		switch b.policy {
+		case OverflowDropOldest:
+			b.Deque.PopBack()
+		case OverflowDropNewest:
+			b.Deque.PopFront()
+		default:
+			return false
+		}
+	}
+	b.Deque.PushFront(x)
+	return true
+}
+
+// OfferBack adds an element to the back of the deque
+// false if the element is rejected because the deque is full
+func (b *boundedDeque[X]) OfferBack(x X) bool {
+	if b.Full() {
+		switch b.policy {
+		case OverflowDropOldest:
+			b.Deque.PopFront()
+		case OverflowDropNewest:
+			b.Deque.PopBack()
+		default:
+			return false
+		}
+	}
+	b.Deque.PushBack(x)
+	return true
+}
+
+// ExtendBack adds the elements to the back of the deque in order
+func (b *boundedDeque[X]) ExtendBack(xs ...X) {
+	for _, x := range xs {
+		b.OfferBack(x)
+	}
+}
+
+// ExtendFront adds the elements to the front of the deque one at a time
+func (b *boundedDeque[X]) ExtendFront(xs ...X) {
+	for _, x := range xs {
+		b.OfferFront(x)
+	}
+}
+
+// Insert adds an element at the position
+func (b *boundedDeque[X]) Insert(i int, x X) error {
+	size := b.Deque.Size()
+	if i < 0 || i > size {
+		return indexOutOfRange(i, size)
+	}
+
+	if b.Full() {
+		switch b.policy {
+		case OverflowDropOldest:
+			b.Deque.PopFront()
+			// the elements shift towards the front
+			i = max(i-1, 0)
+		case OverflowDropNewest:
+			b.Deque.PopBack()
+			i = min(i, size-1)
+		default:
+			return ErrDequeFull
+		}
+	}
+	return b.Deque.Insert(i, x)
+}
+
+// Capacity returns the maximum number of elements in the deque
+func (b *boundedDeque[X]) Capacity() int {
+	return b.capacity
+}
+
+// Full returns if the deque has reached its capacity
+func (b *boundedDeque[X]) Full() bool {
+	return b.Deque.Size() >= b.capacity
+}
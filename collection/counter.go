@@ -0,0 +1,160 @@
+package collection
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Counter counts the occurrences of elements, like a multiset.
+// Only positive counts are kept: an element whose count drops to zero or
+// below is removed. The elements are kept in the order they were first
+// counted, which breaks the ties of MostCommon.
+type Counter[X comparable] struct {
+	counts *OrderedMap[X, int]
+	total  int
+}
+
+// CounterEntry is an element and its count
+type CounterEntry[X comparable] struct {
+	Element X
+	Count   int
+}
+
+// NewCounter creates a new counter counting the elements
+func NewCounter[X comparable](xs ...X) *Counter[X] {
+	c := &Counter[X]{counts: NewOrderedMap[X, int]()}
+	for _, x := range xs {
+		c.Add(x)
+	}
+	return c
+}
+
+// Add counts one occurrence of the element and returns its count
+func (c *Counter[X]) Add(x X) int {
+	return c.AddN(x, 1)
+}
+
+// AddN adds n to the count of the element and returns its count.
+// A negative n subtracts, and the element is removed once its count
+// is not positive.
+func (c *Counter[X]) AddN(x X, n int) int {
+	count, _ := c.counts.Get(x)
+	c.set(x, count+n)
+	return max(count+n, 0)
+}
+
+// set sets the count of the element, removing it if not positive
+func (c *Counter[X]) set(x X, count int) {
+	previous, _ := c.counts.Get(x)
+	if count <= 0 {
+		c.counts.Delete(x)
+		count = 0
+	} else {
+		c.counts.Set(x, count)
+	}
+	c.total += count - previous
+}
+
+// Count returns the count of the element, zero if not counted
+func (c *Counter[X]) Count(x X) int {
+	count, _ := c.counts.Get(x)
+	return count
+}
+
+// Delete removes the element and returns its count
+func (c *Counter[X]) Delete(x X) int {
+	count := c.Count(x)
+	c.set(x, 0)
+	return count
+}
+
+// Total returns the sum of the counts
+func (c *Counter[X]) Total() int {
+	return c.total
+}
+
+// Len returns the number of distinct elements
+func (c *Counter[X]) Len() int {
+	return c.counts.Len()
+}
+
+// Empty returns true if no element is counted
+func (c *Counter[X]) Empty() bool {
+	return c.counts.Len() == 0
+}
+
+// Clear removes all the elements
+func (c *Counter[X]) Clear() {
+	c.counts.Clear()
+	c.total = 0
+}
+
+// MostCommon returns the n elements with the highest counts from the
+// most common to the least, all the elements if n is negative.
+// Elements with equal counts are in the order they were first counted.
+func (c *Counter[X]) MostCommon(n int) []CounterEntry[X] {
+	entries := make([]CounterEntry[X], 0, c.counts.Len())
+	for x, count := range c.counts.All() {
+		entries = append(entries, CounterEntry[X]{Element: x, Count: count})
+	}
+	slices.SortStableFunc(entries, func(a, b CounterEntry[X]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	if n >= 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// All returns an iterator over the elements and their counts
+// in the order they were first counted
+func (c *Counter[X]) All() iter.Seq2[X, int] {
+	return c.counts.All()
+}
+
+// Arithmetic, each operation returns a new counter
+
+// Plus returns the sum of the counts of both counters
+func (c *Counter[X]) Plus(other *Counter[X]) *Counter[X] {
+	return c.combine(other, func(a, b int) int {
+		return a + b
+	})
+}
+
+// Minus returns the counts of this counter minus the counts of the other,
+// keeping only the positive counts
+func (c *Counter[X]) Minus(other *Counter[X]) *Counter[X] {
+	return c.combine(other, func(a, b int) int {
+		return a - b
+	})
+}
+
+// Union returns the maximum of the counts of both counters
+func (c *Counter[X]) Union(other *Counter[X]) *Counter[X] {
+	return c.combine(other, func(a, b int) int {
+		return max(a, b)
+	})
+}
+
+// Intersection returns the minimum of the counts of both counters
+func (c *Counter[X]) Intersection(other *Counter[X]) *Counter[X] {
+	return c.combine(other, func(a, b int) int {
+		return min(a, b)
+	})
+}
+
+// combine applies the operation to the counts of every element counted
+// by either counter, the elements of this counter first
+func (c *Counter[X]) combine(other *Counter[X], operation func(a, b int) int) *Counter[X] {
+	result := NewCounter[X]()
+	for x, count := range c.counts.All() {
+		result.set(x, operation(count, other.Count(x)))
+	}
+	for x, count := range other.counts.All() {
+		if !c.counts.Contains(x) {
+			result.set(x, operation(0, count))
+		}
+	}
+	return result
+}
@@ -1,6 +1,74 @@
 package collection
 
-type Deque[X comparable] interface {
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrIndexOutOfRange is returned when accessing a position outside of a deque
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// ReadOnlyDeque is the part of Deque which views the elements without
+// modifying them. Every Deque is a ReadOnlyDeque, and so is every version
+// of a PersistentDeque.
+type ReadOnlyDeque[X any] interface {
+	// Peek views the top element, i.e. the back element
+	Peek() (X, bool)
+
+	// Top is an alias for Peek
+	Top() (X, bool)
+
+	// Front views the first element
+	Front() (X, bool)
+
+	// Back views the last element
+	Back() (X, bool)
+
+	// At views the element at the position from the front
+	// ErrIndexOutOfRange if there is no element at the position
+	At(i int) (X, error)
+
+	// Empty returns true if there is zero element
+	Empty() bool
+
+	// Size returns the total number of elements
+	Size() int
+
+	// Contains checks if the element exists
+	Contains(x X) bool
+
+	// ContainsFunc checks if an element satisfying the predicate exists
+	ContainsFunc(predicate func(X) bool) bool
+
+	// IndexFunc returns the position from the front of the first element
+	// satisfying the predicate, -1 if there is none
+	IndexFunc(predicate func(X) bool) int
+
+	// ToSlice returns the elements from the front to the back
+	ToSlice() []X
+
+	// All returns an iterator over the elements from the front to the back
+	All() iter.Seq[X]
+
+	// Backward returns an iterator over the elements from the back to the front
+	Backward() iter.Seq[X]
+
+	// Enumerate returns an iterator over the position and the element
+	// from the front to the back
+	Enumerate() iter.Seq2[int, X]
+}
+
+// Deque is a double ended queue which can be used as a stack or a queue.
+// The top of the stack is the back of the queue.
+//
+// Elements of any type can be stored. Contains, Remove and RemoveAll
+// compare the elements with the equality function given to the
+// constructor, the constructors for comparable elements use the ==
+// operator. Given a nil equality function, they fall back to the ==
+// operator and panic if the elements are not comparable, while
+// ContainsFunc, IndexFunc, RemoveFunc and RemoveAllFunc still work.
+type Deque[X any] interface {
 
 	// Push adds an element to the top of the deque
 	Push(x X)
@@ -30,6 +98,78 @@ type Deque[X comparable] interface {
 	// Back views the last element of the deque
 	Back() (X, bool)
 
+	// Double ended specifics
+
+	// PushFront adds an element to the front of the deque
+	PushFront(x X)
+
+	// PushBack adds an element to the back of the deque
+	// This is equivalent to Push and Enqueue.
+	PushBack(x X)
+
+	// PopFront removes the front element from the deque
+	// This is equivalent to Dequeue.
+	PopFront() (X, bool)
+
+	// PopBack removes the back element from the deque
+	// This is equivalent to Pop.
+	PopBack() (X, bool)
+
+	// Indexed access
+	// The positions are counted from the front of the deque,
+	// the front element is at position 0.
+
+	// At views the element at the position
+	// ErrIndexOutOfRange if there is no element at the position
+	At(i int) (X, error)
+
+	// Set replaces the element at the position
+	// ErrIndexOutOfRange if there is no element at the position
+	Set(i int, x X) error
+
+	// Insert adds an element at the position, shifting the element at the
+	// position and the ones after it towards the back. Inserting at Size()
+	// adds the element to the back.
+	// ErrIndexOutOfRange if the position is negative or greater than Size()
+	Insert(i int, x X) error
+
+	// RemoveAt removes the element at the position
+	// ErrIndexOutOfRange if there is no element at the position
+	RemoveAt(i int) (X, error)
+
+	// Bulk operations
+
+	// Rotate rotates the deque n steps to the back, i.e. the last n elements
+	// move to the front. A negative n rotates to the front.
+	Rotate(n int)
+
+	// Swap exchanges the elements at the two positions
+	// ErrIndexOutOfRange if there is no element at either position
+	Swap(i, j int) error
+
+	// ExtendBack adds the elements to the back of the deque in order
+	ExtendBack(xs ...X)
+
+	// ExtendFront adds the elements to the front of the deque one at a time,
+	// so the elements end up in reverse order like Python's
+	// deque.extendleft, e.g. ExtendFront(1, 2) on [3] results in [2, 1, 3].
+	ExtendFront(xs ...X)
+
+	// DrainTo moves up to max elements from the front of the deque to the
+	// back of dst in order, all the elements if max is negative. It returns
+	// the number of elements moved.
+	// A BoundedDeque dst is offered the elements, draining stops at the
+	// first element rejected which stays at the front of the deque.
+	DrainTo(dst Deque[X], max int) int
+
+	// RemoveAll removes every occurrence of an element in the deque
+	// and returns the number of elements removed
+	RemoveAll(x X) int
+
+	// RemoveAllFunc removes every element satisfying the predicate
+	// and returns the number of elements removed
+	RemoveAllFunc(predicate func(X) bool) int
+
 	// Others
 
 	// Empty returns if the deque has at least one element
@@ -44,11 +184,109 @@ type Deque[X comparable] interface {
 	// Contains checks if the element exists in the deque
 	Contains(x X) bool
 
+	// ContainsFunc checks if an element satisfying the predicate
+	// exists in the deque
+	ContainsFunc(predicate func(X) bool) bool
+
+	// IndexFunc returns the position from the front of the first element
+	// satisfying the predicate, -1 if there is none
+	IndexFunc(predicate func(X) bool) int
+
 	// Reverse reverses the deque
 	Reverse()
 
 	ToSlice() []X
 
+	// Iterators
+	// Modifying the deque while iterating is not supported unless
+	// documented otherwise by the implementation.
+
+	// All returns an iterator over the elements from the front to the back
+	All() iter.Seq[X]
+
+	// Backward returns an iterator over the elements from the back to the front
+	Backward() iter.Seq[X]
+
+	// Enumerate returns an iterator over the position and the element
+	// from the front to the back, the front element is at position 0
+	Enumerate() iter.Seq2[int, X]
+
 	// Remove removes the first occurrence of an element in the deque
 	Remove(x X) bool
+
+	// RemoveFunc removes the first element satisfying the predicate
+	RemoveFunc(predicate func(X) bool) bool
+}
+
+// drain moves up to max elements from the front of src to the back of dst.
+// Only the elements in src when draining starts are moved, so draining a
+// deque into itself terminates.
+func drain[X any](src Deque[X], dst Deque[X], max int) int {
+	n := src.Size()
+	if max >= 0 {
+		n = min(n, max)
+	}
+
+	offer := func(x X) bool {
+		dst.PushBack(x)
+		return true
+	}
+	if bounded, ok := dst.(BoundedDeque[X]); ok {
+		offer = bounded.OfferBack
+	}
+	for i := 0; i < n; i++ {
+		// the element is popped first to make room when draining into itself
+		x, _ := src.PopFront()
+		if !offer(x) {
+			src.PushFront(x)
+			return i
+		}
+	}
+	return n
+}
+
+// rotation normalizes a rotation of n steps to the back
+// into the range [0, size)
+func rotation(n int, size int) int {
+	if size == 0 {
+		return 0
+	}
+	n %= size
+	if n < 0 {
+		n += size
+	}
+	return n
+}
+
+// indexOutOfRange reports a position outside of a deque of the size
+func indexOutOfRange(i int, size int) error {
+	return fmt.Errorf("%w: index %d with size %d", ErrIndexOutOfRange, i, size)
+}
+
+// equalComparable compares the elements with the == operator
+func equalComparable[X comparable](a, b X) bool {
+	return a == b
+}
+
+// equalityOf returns the equality function of a deque of this package,
+// nil for the other implementations
+func equalityOf[X any](deque Deque[X]) func(a, b X) bool {
+	if d, ok := deque.(interface{ equality() func(a, b X) bool }); ok {
+		return d.equality()
+	}
+	return nil
+}
+
+// equalTo returns a predicate matching the elements equal to x.
+// Without an equality function, the elements are compared with the ==
+// operator which panics if the elements are not comparable.
+func equalTo[X any](equal func(a, b X) bool, x X) func(X) bool {
+	if equal == nil {
+		return func(y X) bool {
+			return any(x) == any(y)
+		}
+	}
+	return func(y X) bool {
+		return equal(x, y)
+	}
 }
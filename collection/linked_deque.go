@@ -1,58 +1,45 @@
 package collection
 
-type linkedNode[X comparable] struct {
+import "iter"
+
+type linkedNode[X any] struct {
 	x    X
 	next *linkedNode[X]
 	prev *linkedNode[X]
 }
 
-type LinkedDeque[X comparable] struct {
-	head *linkedNode[X]
-	last *linkedNode[X]
-	size int
+type LinkedDeque[X any] struct {
+	head  *linkedNode[X]
+	last  *linkedNode[X]
+	size  int
+	equal func(a, b X) bool
 }
 
+// NewLinkedDeque creates a new deque backed by a doubly linked list
 func NewLinkedDeque[X comparable]() Deque[X] {
-	return &LinkedDeque[X]{}
+	return &LinkedDeque[X]{equal: equalComparable[X]}
 }
 
-// Push adds an element to the top of the deque
-func (d *LinkedDeque[X]) Push(x X) {
-	node := &linkedNode[X]{x: x}
-
-	d.size += 1
-	if d.head == nil {
-		d.head = node
-		d.last = node
-		return
-	}
+// NewLinkedDequeFunc creates a new deque backed by a doubly linked list
+// which compares the elements with the equality function.
+// A nil equality function falls back to the == operator.
+func NewLinkedDequeFunc[X any](equal func(a, b X) bool) Deque[X] {
+	return &LinkedDeque[X]{equal: equal}
+}
 
-	currLast := d.last
-	node.prev = currLast
-	currLast.next = node
+// equality returns the equality function of the deque
+func (d *LinkedDeque[X]) equality() func(a, b X) bool {
+	return d.equal
+}
 
-	d.last = node
+// Push adds an element to the top of the deque
+func (d *LinkedDeque[X]) Push(x X) {
+	d.PushBack(x)
 }
 
 // Pop removes the top element from the deque
 func (d *LinkedDeque[X]) Pop() (X, bool) {
-	if d.last == nil {
-		var zero X
-		return zero, false
-	}
-
-	node := d.last
-	if node.prev != nil {
-		d.last = node.prev
-		d.last.next = nil
-	} else {
-		// node is the back element in the deque
-		d.head = nil
-		d.last = nil
-	}
-	d.size -= 1
-
-	return node.x, true
+	return d.PopBack()
 }
 
 // Peek views the top element from the deque
@@ -74,11 +61,78 @@ func (d *LinkedDeque[X]) Top() (X, bool) {
 
 // Enqueue adds an element to the end of the deque
 func (d *LinkedDeque[X]) Enqueue(x X) {
-	d.Push(x)
+	d.PushBack(x)
 }
 
 // Dequeue removes an element from the front of the deque
 func (d *LinkedDeque[X]) Dequeue() (X, bool) {
+	return d.PopFront()
+}
+
+// Front views the first element of the deque
+func (d *LinkedDeque[X]) Front() (X, bool) {
+	if d.head == nil {
+		var zero X
+		return zero, false
+	}
+
+	return d.head.x, true
+}
+
+// Back views the last element of the deque
+func (d *LinkedDeque[X]) Back() (X, bool) {
+	if d.last == nil {
+		var zero X
+		return zero, false
+	}
+
+	return d.last.x, true
+}
+
+// PushFront adds an element to the front of the deque
+func (d *LinkedDeque[X]) PushFront(x X) {
+	d.linkFront(&linkedNode[X]{x: x})
+}
+
+// linkFront adds a detached node to the front of the deque
+func (d *LinkedDeque[X]) linkFront(node *linkedNode[X]) {
+	d.size += 1
+	if d.head == nil {
+		d.head = node
+		d.last = node
+		return
+	}
+
+	currHead := d.head
+	node.next = currHead
+	currHead.prev = node
+
+	d.head = node
+}
+
+// PushBack adds an element to the back of the deque
+func (d *LinkedDeque[X]) PushBack(x X) {
+	d.linkBack(&linkedNode[X]{x: x})
+}
+
+// linkBack adds a detached node to the back of the deque
+func (d *LinkedDeque[X]) linkBack(node *linkedNode[X]) {
+	d.size += 1
+	if d.head == nil {
+		d.head = node
+		d.last = node
+		return
+	}
+
+	currLast := d.last
+	node.prev = currLast
+	currLast.next = node
+
+	d.last = node
+}
+
+// PopFront removes the front element from the deque
+func (d *LinkedDeque[X]) PopFront() (X, bool) {
 	if d.head == nil {
 		var zero X
 		return zero, false
@@ -97,24 +151,25 @@ func (d *LinkedDeque[X]) Dequeue() (X, bool) {
 	return currHead.x, true
 }
 
-// Front views the first element of the deque
-func (d *LinkedDeque[X]) Front() (X, bool) {
-	if d.head == nil {
+// PopBack removes the back element from the deque
+func (d *LinkedDeque[X]) PopBack() (X, bool) {
+	if d.last == nil {
 		var zero X
 		return zero, false
 	}
 
-	return d.head.x, true
-}
-
-// Back views the last element of the deque
-func (d *LinkedDeque[X]) Back() (X, bool) {
-	if d.last == nil {
-		var zero X
-		return zero, false
+	node := d.last
+	if node.prev != nil {
+		d.last = node.prev
+		d.last.next = nil
+	} else {
+		// node is the back element in the deque
+		d.head = nil
+		d.last = nil
 	}
+	d.size -= 1
 
-	return d.last.x, true
+	return node.x, true
 }
 
 // Empty returns if the deque has at least one element
@@ -136,12 +191,26 @@ func (d *LinkedDeque[X]) Clear() {
 
 // Contains checks if the element exists in the deque
 func (d *LinkedDeque[X]) Contains(x X) bool {
+	return d.ContainsFunc(equalTo(d.equal, x))
+}
+
+// ContainsFunc checks if an element satisfying the predicate
+// exists in the deque
+func (d *LinkedDeque[X]) ContainsFunc(predicate func(X) bool) bool {
+	return d.IndexFunc(predicate) >= 0
+}
+
+// IndexFunc returns the position from the front of the first element
+// satisfying the predicate, -1 if there is none
+func (d *LinkedDeque[X]) IndexFunc(predicate func(X) bool) int {
+	i := 0
 	for node := d.head; node != nil; node = node.next {
-		if node.x == x {
-			return true
+		if predicate(node.x) {
+			return i
 		}
+		i++
 	}
-	return false
+	return -1
 }
 
 // Reverse reverses the deque
@@ -165,25 +234,215 @@ func (d *LinkedDeque[X]) ToSlice() []X {
 	return slice
 }
 
-// Remove removes the first occurrence of an element in the deque
-func (d *LinkedDeque[X]) Remove(x X) bool {
-	for node := d.head; node != nil; node = node.next {
-		if node.x == x {
-			if node.prev != nil {
-				node.prev.next = node.next
-			} else {
-				d.head = node.next
+// All returns an iterator over the elements from the front to the back
+func (d *LinkedDeque[X]) All() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for node := d.head; node != nil; node = node.next {
+			if !yield(node.x) {
+				return
 			}
+		}
+	}
+}
 
-			if node.next != nil {
-				node.next.prev = node.prev
-			} else {
-				d.last = node.prev
+// Backward returns an iterator over the elements from the back to the front
+func (d *LinkedDeque[X]) Backward() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for node := d.last; node != nil; node = node.prev {
+			if !yield(node.x) {
+				return
 			}
+		}
+	}
+}
+
+// Enumerate returns an iterator over the position and the element
+// from the front to the back
+func (d *LinkedDeque[X]) Enumerate() iter.Seq2[int, X] {
+	return func(yield func(int, X) bool) {
+		i := 0
+		for node := d.head; node != nil; node = node.next {
+			if !yield(i, node.x) {
+				return
+			}
+			i++
+		}
+	}
+}
 
-			d.size -= 1
+// Remove removes the first occurrence of an element in the deque
+func (d *LinkedDeque[X]) Remove(x X) bool {
+	return d.RemoveFunc(equalTo(d.equal, x))
+}
+
+// RemoveFunc removes the first element satisfying the predicate
+func (d *LinkedDeque[X]) RemoveFunc(predicate func(X) bool) bool {
+	for node := d.head; node != nil; node = node.next {
+		if predicate(node.x) {
+			d.unlink(node)
 			return true
 		}
 	}
 	return false
 }
+
+// At views the element at the position
+func (d *LinkedDeque[X]) At(i int) (X, error) {
+	if i < 0 || i >= d.size {
+		var zero X
+		return zero, indexOutOfRange(i, d.size)
+	}
+	return d.nodeAt(i).x, nil
+}
+
+// Set replaces the element at the position
+func (d *LinkedDeque[X]) Set(i int, x X) error {
+	if i < 0 || i >= d.size {
+		return indexOutOfRange(i, d.size)
+	}
+	d.nodeAt(i).x = x
+	return nil
+}
+
+// Insert adds an element at the position
+// The position is reached from the closer end in O(min(i, n-i)) time.
+func (d *LinkedDeque[X]) Insert(i int, x X) error {
+	if i < 0 || i > d.size {
+		return indexOutOfRange(i, d.size)
+	}
+	if i == 0 {
+		d.PushFront(x)
+		return nil
+	}
+	if i == d.size {
+		d.PushBack(x)
+		return nil
+	}
+
+	// link the new node in front of the node at the position
+	next := d.nodeAt(i)
+	node := &linkedNode[X]{x: x, prev: next.prev, next: next}
+	next.prev.next = node
+	next.prev = node
+	d.size += 1
+	return nil
+}
+
+// RemoveAt removes the element at the position
+// The position is reached from the closer end in O(min(i, n-i)) time.
+func (d *LinkedDeque[X]) RemoveAt(i int) (X, error) {
+	if i < 0 || i >= d.size {
+		var zero X
+		return zero, indexOutOfRange(i, d.size)
+	}
+	node := d.nodeAt(i)
+	d.unlink(node)
+	return node.x, nil
+}
+
+// nodeAt walks to the node at the position from the closer end
+// The position must be within the deque.
+func (d *LinkedDeque[X]) nodeAt(i int) *linkedNode[X] {
+	if i < d.size/2 {
+		node := d.head
+		for ; i > 0; i-- {
+			node = node.next
+		}
+		return node
+	}
+
+	node := d.last
+	for j := d.size - 1; j > i; j-- {
+		node = node.prev
+	}
+	return node
+}
+
+// unlink removes the node from the deque
+func (d *LinkedDeque[X]) unlink(node *linkedNode[X]) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		d.head = node.next
+	}
+
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		d.last = node.prev
+	}
+
+	node.prev = nil
+	node.next = nil
+	d.size -= 1
+}
+
+// Rotate rotates the deque n steps to the back
+func (d *LinkedDeque[X]) Rotate(n int) {
+	n = rotation(n, d.size)
+	if n == 0 {
+		return
+	}
+
+	// close the ring then cut it in front of the new head
+	newHead := d.nodeAt(d.size - n)
+	d.last.next = d.head
+	d.head.prev = d.last
+
+	d.head = newHead
+	d.last = newHead.prev
+	d.head.prev = nil
+	d.last.next = nil
+}
+
+// Swap exchanges the elements at the two positions
+func (d *LinkedDeque[X]) Swap(i, j int) error {
+	if i < 0 || i >= d.size {
+		return indexOutOfRange(i, d.size)
+	}
+	if j < 0 || j >= d.size {
+		return indexOutOfRange(j, d.size)
+	}
+
+	a, b := d.nodeAt(i), d.nodeAt(j)
+	a.x, b.x = b.x, a.x
+	return nil
+}
+
+// ExtendBack adds the elements to the back of the deque in order
+func (d *LinkedDeque[X]) ExtendBack(xs ...X) {
+	for _, x := range xs {
+		d.PushBack(x)
+	}
+}
+
+// ExtendFront adds the elements to the front of the deque one at a time
+func (d *LinkedDeque[X]) ExtendFront(xs ...X) {
+	for _, x := range xs {
+		d.PushFront(x)
+	}
+}
+
+// DrainTo moves up to max elements from the front of the deque to dst
+func (d *LinkedDeque[X]) DrainTo(dst Deque[X], max int) int {
+	return drain[X](d, dst, max)
+}
+
+// RemoveAll removes every occurrence of an element in the deque
+func (d *LinkedDeque[X]) RemoveAll(x X) int {
+	return d.RemoveAllFunc(equalTo(d.equal, x))
+}
+
+// RemoveAllFunc removes every element satisfying the predicate
+func (d *LinkedDeque[X]) RemoveAllFunc(predicate func(X) bool) int {
+	removed := 0
+	for node := d.head; node != nil; {
+		next := node.next
+		if predicate(node.x) {
+			d.unlink(node)
+			removed++
+		}
+		node = next
+	}
+	return removed
+}
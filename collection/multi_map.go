@@ -0,0 +1,141 @@
+package collection
+
+import "iter"
+
+// MultiMap is a map from a key to a list of values. The values of a key
+// are kept in a deque in the order they were added, and the keys are
+// kept in the order they were first added.
+type MultiMap[K comparable, V any] struct {
+	entries  *OrderedMap[K, Deque[V]]
+	size     int
+	newDeque func() Deque[V]
+}
+
+// NewMultiMap creates a new multimap storing the values of each key
+// in a ring deque
+func NewMultiMap[K comparable, V comparable]() *MultiMap[K, V] {
+	return NewMultiMapFunc[K](NewRingDeque[V])
+}
+
+// NewMultiMapFunc creates a new multimap storing the values of each key
+// in a deque created by newDeque. A BoundedDeque limits the number of
+// values of each key with its overflow policy.
+func NewMultiMapFunc[K comparable, V any](newDeque func() Deque[V]) *MultiMap[K, V] {
+	return &MultiMap[K, V]{
+		entries:  NewOrderedMap[K, Deque[V]](),
+		newDeque: newDeque,
+	}
+}
+
+// Put adds the values to the back of the values of the key
+func (m *MultiMap[K, V]) Put(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	deque, ok := m.entries.Get(key)
+	if !ok {
+		deque = m.newDeque()
+		m.entries.Set(key, deque)
+	}
+	// a bounded deque may reject or evict values
+	size := deque.Size()
+	deque.ExtendBack(values...)
+	m.size += deque.Size() - size
+}
+
+// Get returns the values of the key from the first added to the last
+// nil if the key is not in the map
+func (m *MultiMap[K, V]) Get(key K) []V {
+	deque, ok := m.entries.Get(key)
+	if !ok {
+		return nil
+	}
+	return deque.ToSlice()
+}
+
+// Remove removes the first occurrence of the value from the values
+// of the key, and the key once it has no value left
+// false if the value is not found
+func (m *MultiMap[K, V]) Remove(key K, value V) bool {
+	deque, ok := m.entries.Get(key)
+	if !ok || !deque.Remove(value) {
+		return false
+	}
+	if deque.Empty() {
+		m.entries.Delete(key)
+	}
+	m.size -= 1
+	return true
+}
+
+// Delete removes the key and returns its values
+// nil if the key is not in the map
+func (m *MultiMap[K, V]) Delete(key K) []V {
+	deque, ok := m.entries.Get(key)
+	if !ok {
+		return nil
+	}
+	m.entries.Delete(key)
+	m.size -= deque.Size()
+	return deque.ToSlice()
+}
+
+// Contains checks if the value is one of the values of the key
+func (m *MultiMap[K, V]) Contains(key K, value V) bool {
+	deque, ok := m.entries.Get(key)
+	return ok && deque.Contains(value)
+}
+
+// ContainsKey checks if the key has at least one value
+func (m *MultiMap[K, V]) ContainsKey(key K) bool {
+	return m.entries.Contains(key)
+}
+
+// Count returns the number of values of the key
+func (m *MultiMap[K, V]) Count(key K) int {
+	deque, ok := m.entries.Get(key)
+	if !ok {
+		return 0
+	}
+	return deque.Size()
+}
+
+// Len returns the total number of values in the map
+func (m *MultiMap[K, V]) Len() int {
+	return m.size
+}
+
+// KeyLen returns the total number of keys in the map
+func (m *MultiMap[K, V]) KeyLen() int {
+	return m.entries.Len()
+}
+
+// Empty returns true if the map has zero value
+func (m *MultiMap[K, V]) Empty() bool {
+	return m.size == 0
+}
+
+// Clear removes all keys from the map
+func (m *MultiMap[K, V]) Clear() {
+	m.entries.Clear()
+	m.size = 0
+}
+
+// Keys returns an iterator over the keys in the order they were first added
+func (m *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return m.entries.Keys()
+}
+
+// All returns an iterator over every key and value pair, the keys in the
+// order they were first added and the values of each key in order
+func (m *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, deque := range m.entries.All() {
+			for value := range deque.All() {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package collection
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// OrderedMap is a map which remembers the order in which the keys
+// were first inserted, like Java's LinkedHashMap.
+// Get, Set, Delete, MoveToFront and MoveToBack take constant time.
+// The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*linkedNode[orderedEntry[K, V]]
+	// order keeps the entries from the oldest to the newest key
+	order LinkedDeque[orderedEntry[K, V]]
+}
+
+type orderedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewOrderedMap creates a new empty OrderedMap
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		entries: map[K]*linkedNode[orderedEntry[K, V]]{},
+	}
+}
+
+// Get returns the value of the key
+// a zero value and a false if the key is not in the map
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	node, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return node.x.value, true
+}
+
+// Set sets the value of the key
+// A new key is added to the back, an existing key keeps its position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if node, ok := m.entries[key]; ok {
+		node.x.value = value
+		return
+	}
+	if m.entries == nil {
+		m.entries = map[K]*linkedNode[orderedEntry[K, V]]{}
+	}
+
+	node := &linkedNode[orderedEntry[K, V]]{x: orderedEntry[K, V]{key: key, value: value}}
+	m.order.linkBack(node)
+	m.entries[key] = node
+}
+
+// Delete removes the key from the map
+// false if the key is not in the map
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	node, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.order.unlink(node)
+	delete(m.entries, key)
+	return true
+}
+
+// Contains checks if the key exists in the map
+func (m *OrderedMap[K, V]) Contains(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Len returns the total number of keys in the map
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Clear removes all keys from the map
+func (m *OrderedMap[K, V]) Clear() {
+	clear(m.entries)
+	m.order.Clear()
+}
+
+// MoveToFront moves the key to the front of the order
+// false if the key is not in the map
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	node, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.order.unlink(node)
+	m.order.linkFront(node)
+	return true
+}
+
+// MoveToBack moves the key to the back of the order
+// false if the key is not in the map
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	node, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	m.order.unlink(node)
+	m.order.linkBack(node)
+	return true
+}
+
+// Front views the first key and its value
+// zero values and a false if the map is empty
+func (m *OrderedMap[K, V]) Front() (K, V, bool) {
+	entry, ok := m.order.Front()
+	return entry.key, entry.value, ok
+}
+
+// Back views the last key and its value
+// zero values and a false if the map is empty
+func (m *OrderedMap[K, V]) Back() (K, V, bool) {
+	entry, ok := m.order.Back()
+	return entry.key, entry.value, ok
+}
+
+// PopFront removes the first key and returns it with its value
+// zero values and a false if the map is empty
+func (m *OrderedMap[K, V]) PopFront() (K, V, bool) {
+	entry, ok := m.order.PopFront()
+	if ok {
+		delete(m.entries, entry.key)
+	}
+	return entry.key, entry.value, ok
+}
+
+// PopBack removes the last key and returns it with its value
+// zero values and a false if the map is empty
+func (m *OrderedMap[K, V]) PopBack() (K, V, bool) {
+	entry, ok := m.order.PopBack()
+	if ok {
+		delete(m.entries, entry.key)
+	}
+	return entry.key, entry.value, ok
+}
+
+// All returns an iterator over the keys and values from the front
+// to the back. The current key and the keys already visited may be deleted
+// or moved while iterating, the iteration stops at the key which was the
+// last one when it started. Deleting or moving the keys not visited yet
+// is not supported and may end the iteration early.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		last := m.order.last
+		for node := m.order.head; node != nil; {
+			next := node.next
+			if !yield(node.x.key, node.x.value) || node == last {
+				return
+			}
+			node = next
+		}
+	}
+}
+
+// Backward returns an iterator over the keys and values from the back
+// to the front. The current key and the keys already visited may be deleted
+// or moved while iterating, the iteration stops at the key which was the
+// first one when it started. Deleting or moving the keys not visited yet
+// is not supported and may end the iteration early.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		first := m.order.head
+		for node := m.order.last; node != nil; {
+			prev := node.prev
+			if !yield(node.x.key, node.x.value) || node == first {
+				return
+			}
+			node = prev
+		}
+	}
+}
+
+// Keys returns an iterator over the keys from the front to the back
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values from the front to the back
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON encodes the map as a JSON object keeping the order of the keys.
+// The keys are encoded like encoding/json encodes map keys: strings,
+// integers and encoding.TextMarshaler implementations are supported.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	first := true
+	for key, value := range m.All() {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		encodedKey, err := marshalJSONKey(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+
+		encodedValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalJSONKey encodes the key as a JSON string
+// following the precedence of encoding/json for map keys
+func marshalJSONKey[K comparable](key K) ([]byte, error) {
+	v := reflect.ValueOf(key)
+	if v.Kind() == reflect.String {
+		return json.Marshal(v.String())
+	}
+	if marshaler, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(string(text))
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Marshal(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Marshal(strconv.FormatUint(v.Uint(), 10))
+	}
+	return nil, fmt.Errorf("collection: unsupported JSON key type %T", key)
+}
@@ -0,0 +1,624 @@
+package collection
+
+import "iter"
+
+// persistentBalance bounds how much longer one list of a PersistentDeque
+// may grow compared to the other before the elements are rebalanced
+const persistentBalance = 3
+
+// PersistentDeque is an immutable deque. Adding or removing an element
+// returns a new version of the deque which shares most of its structure
+// with the previous version, which stays unchanged. Versions are safe to
+// share between goroutines without locking.
+//
+// It is a banker's deque: the front elements are kept in one linked list
+// and the back elements in reverse in another, which are rebalanced once
+// one grows too long. Adding and removing at both ends take amortized
+// constant time as long as each version is modified at most once,
+// At takes linear time.
+//
+// The zero value is an empty deque comparing the elements with ==.
+type PersistentDeque[X any] struct {
+	front     *persistentNode[X]
+	frontSize int
+	// rear holds the back elements, the head being the back element
+	rear     *persistentNode[X]
+	rearSize int
+	equal    func(a, b X) bool
+}
+
+// persistentNode is a node of an immutable singly linked list
+type persistentNode[X any] struct {
+	x    X
+	next *persistentNode[X]
+}
+
+// NewPersistentDeque creates a new persistent deque holding the elements
+// from the front to the back
+func NewPersistentDeque[X comparable](xs ...X) PersistentDeque[X] {
+	return NewPersistentDequeFunc(equalComparable[X], xs...)
+}
+
+// NewPersistentDequeFunc creates a new persistent deque holding the elements
+// from the front to the back which compares the elements with the equality
+// function. A nil equality function falls back to the == operator.
+func NewPersistentDequeFunc[X any](equal func(a, b X) bool, xs ...X) PersistentDeque[X] {
+	half := len(xs) / 2
+	return PersistentDeque[X]{
+		front:     listOf(xs[:half]),
+		frontSize: half,
+		rear:      reversedListOf(xs[half:]),
+		rearSize:  len(xs) - half,
+		equal:     equal,
+	}
+}
+
+// NewPersistentDequeOf creates a new persistent deque holding the elements
+// of the deque from the front to the back. The elements are compared like
+// in the deque when it is from this package, with the == operator otherwise.
+func NewPersistentDequeOf[X any](deque Deque[X]) PersistentDeque[X] {
+	if d, ok := deque.(SnapshotDeque[X]); ok {
+		return d.Snapshot()
+	}
+	return NewPersistentDequeFunc(equalityOf(deque), deque.ToSlice()...)
+}
+
+// listOf links the elements in order
+func listOf[X any](xs []X) *persistentNode[X] {
+	var list *persistentNode[X]
+	for i := len(xs) - 1; i >= 0; i-- {
+		list = &persistentNode[X]{x: xs[i], next: list}
+	}
+	return list
+}
+
+// reversedListOf links the elements in reverse order
+func reversedListOf[X any](xs []X) *persistentNode[X] {
+	var list *persistentNode[X]
+	for _, x := range xs {
+		list = &persistentNode[X]{x: x, next: list}
+	}
+	return list
+}
+
+// balanced rebalances the two lists if one has grown too long, so that
+// both lists have an element whenever the deque has two or more
+func (d PersistentDeque[X]) balanced() PersistentDeque[X] {
+	if d.frontSize > persistentBalance*d.rearSize+1 || d.rearSize > persistentBalance*d.frontSize+1 {
+		return NewPersistentDequeFunc(d.equal, d.ToSlice()...)
+	}
+	return d
+}
+
+// PushFront returns a new version with the element added to the front
+func (d PersistentDeque[X]) PushFront(x X) PersistentDeque[X] {
+	d.front = &persistentNode[X]{x: x, next: d.front}
+	d.frontSize += 1
+	return d.balanced()
+}
+
+// PushBack returns a new version with the element added to the back
+func (d PersistentDeque[X]) PushBack(x X) PersistentDeque[X] {
+	d.rear = &persistentNode[X]{x: x, next: d.rear}
+	d.rearSize += 1
+	return d.balanced()
+}
+
+// PopFront returns the front element and a new version without it
+// a zero value, the same version and a false if the deque is empty
+func (d PersistentDeque[X]) PopFront() (X, PersistentDeque[X], bool) {
+	if d.front == nil {
+		// at most one element is left, which is in the rear
+		return d.popRear()
+	}
+	x := d.front.x
+	d.front = d.front.next
+	d.frontSize -= 1
+	return x, d.balanced(), true
+}
+
+// PopBack returns the back element and a new version without it
+// a zero value, the same version and a false if the deque is empty
+func (d PersistentDeque[X]) PopBack() (X, PersistentDeque[X], bool) {
+	if d.rear == nil {
+		if d.front == nil {
+			var zero X
+			return zero, d, false
+		}
+		// the only element is in the front
+		x := d.front.x
+		d.front = nil
+		d.frontSize = 0
+		return x, d, true
+	}
+	return d.popRear()
+}
+
+func (d PersistentDeque[X]) popRear() (X, PersistentDeque[X], bool) {
+	if d.rear == nil {
+		var zero X
+		return zero, d, false
+	}
+	x := d.rear.x
+	d.rear = d.rear.next
+	d.rearSize -= 1
+	return x, d.balanced(), true
+}
+
+// Peek views the top element, i.e. the back element
+func (d PersistentDeque[X]) Peek() (X, bool) {
+	return d.Back()
+}
+
+// Top is an alias for Peek
+func (d PersistentDeque[X]) Top() (X, bool) {
+	return d.Back()
+}
+
+// Front views the first element
+func (d PersistentDeque[X]) Front() (X, bool) {
+	switch {
+	case d.front != nil:
+		return d.front.x, true
+	case d.rear != nil:
+		return d.rear.x, true
+	}
+	var zero X
+	return zero, false
+}
+
+// Back views the last element
+func (d PersistentDeque[X]) Back() (X, bool) {
+	switch {
+	case d.rear != nil:
+		return d.rear.x, true
+	case d.front != nil:
+		return d.front.x, true
+	}
+	var zero X
+	return zero, false
+}
+
+// At views the element at the position from the front
+func (d PersistentDeque[X]) At(i int) (X, error) {
+	size := d.Size()
+	if i < 0 || i >= size {
+		var zero X
+		return zero, indexOutOfRange(i, size)
+	}
+
+	node, steps := d.front, i
+	if i >= d.frontSize {
+		node, steps = d.rear, size-1-i
+	}
+	for ; steps > 0; steps-- {
+		node = node.next
+	}
+	return node.x, nil
+}
+
+// Empty returns true if the deque has zero element
+func (d PersistentDeque[X]) Empty() bool {
+	return d.Size() == 0
+}
+
+// Size returns the total number of elements in the deque
+func (d PersistentDeque[X]) Size() int {
+	return d.frontSize + d.rearSize
+}
+
+// Contains checks if the element exists in the deque
+func (d PersistentDeque[X]) Contains(x X) bool {
+	return d.ContainsFunc(equalTo(d.equal, x))
+}
+
+// ContainsFunc checks if an element satisfying the predicate
+// exists in the deque
+func (d PersistentDeque[X]) ContainsFunc(predicate func(X) bool) bool {
+	return d.IndexFunc(predicate) >= 0
+}
+
+// IndexFunc returns the position from the front of the first element
+// satisfying the predicate, -1 if there is none
+func (d PersistentDeque[X]) IndexFunc(predicate func(X) bool) int {
+	for i, x := range d.Enumerate() {
+		if predicate(x) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ToSlice returns the elements from the front to the back
+func (d PersistentDeque[X]) ToSlice() []X {
+	slice := make([]X, d.Size())
+	i := 0
+	for node := d.front; node != nil; node = node.next {
+		slice[i] = node.x
+		i++
+	}
+	for j, node := len(slice)-1, d.rear; node != nil; j, node = j-1, node.next {
+		slice[j] = node.x
+	}
+	return slice
+}
+
+// All returns an iterator over the elements from the front to the back
+func (d PersistentDeque[X]) All() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for _, x := range d.Enumerate() {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements from the back to the front
+func (d PersistentDeque[X]) Backward() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for node := d.rear; node != nil; node = node.next {
+			if !yield(node.x) {
+				return
+			}
+		}
+		// the front list only links forward
+		front := make([]X, 0, d.frontSize)
+		for node := d.front; node != nil; node = node.next {
+			front = append(front, node.x)
+		}
+		for i := len(front) - 1; i >= 0; i-- {
+			if !yield(front[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate returns an iterator over the position and the element
+// from the front to the back
+func (d PersistentDeque[X]) Enumerate() iter.Seq2[int, X] {
+	return func(yield func(int, X) bool) {
+		i := 0
+		for node := d.front; node != nil; node = node.next {
+			if !yield(i, node.x) {
+				return
+			}
+			i++
+		}
+		// the rear list only links from the back
+		rear := make([]X, 0, d.rearSize)
+		for node := d.rear; node != nil; node = node.next {
+			rear = append(rear, node.x)
+		}
+		for j := len(rear) - 1; j >= 0; j-- {
+			if !yield(i, rear[j]) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// SnapshotDeque is a Deque backed by a PersistentDeque. Snapshot returns
+// the current version in constant time, and the version is not affected
+// by later modifications of the deque.
+type SnapshotDeque[X any] interface {
+	Deque[X]
+
+	// Snapshot returns the current version of the deque
+	Snapshot() PersistentDeque[X]
+}
+
+// snapshotDeque replaces its version on every modification. Adding and
+// removing at both ends take amortized constant time, the other
+// modifications rebuild the deque in linear time.
+type snapshotDeque[X any] struct {
+	version PersistentDeque[X]
+}
+
+// NewSnapshotDeque creates a new deque backed by a persistent deque
+func NewSnapshotDeque[X comparable]() SnapshotDeque[X] {
+	return NewSnapshotDequeFunc(equalComparable[X])
+}
+
+// NewSnapshotDequeFunc creates a new deque backed by a persistent deque
+// which compares the elements with the equality function.
+// A nil equality function falls back to the == operator.
+func NewSnapshotDequeFunc[X any](equal func(a, b X) bool) SnapshotDeque[X] {
+	return &snapshotDeque[X]{version: PersistentDeque[X]{equal: equal}}
+}
+
+// Snapshot returns the current version of the deque
+func (s *snapshotDeque[X]) Snapshot() PersistentDeque[X] {
+	return s.version
+}
+
+// equality returns the equality function of the deque
+func (s *snapshotDeque[X]) equality() func(a, b X) bool {
+	return s.version.equal
+}
+
+// rebuild applies the modification to a slice deque holding the elements
+// and replaces the version with the result
+func (s *snapshotDeque[X]) rebuild(modify func(Deque[X])) {
+	deque := &sliceDeque[X]{data: s.version.ToSlice(), equal: s.version.equal}
+	modify(deque)
+	s.version = NewPersistentDequeFunc(s.version.equal, deque.ToSlice()...)
+}
+
+// Stack specifics
+
+// Push adds an element to the top of the deque
+func (s *snapshotDeque[X]) Push(x X) {
+	s.PushBack(x)
+}
+
+// Pop removes the top element from the deque
+func (s *snapshotDeque[X]) Pop() (X, bool) {
+	return s.PopBack()
+}
+
+// Peek views the top element from the deque
+func (s *snapshotDeque[X]) Peek() (X, bool) {
+	return s.version.Peek()
+}
+
+// Top views the top element from the deque
+// This is an alias for Peek but provides semantic clarity for stack
+// use cases.
+func (s *snapshotDeque[X]) Top() (X, bool) {
+	return s.version.Top()
+}
+
+// Queue specifics
+
+// Enqueue adds an element to the end of the deque
+func (s *snapshotDeque[X]) Enqueue(x X) {
+	s.PushBack(x)
+}
+
+// Dequeue removes an element from the front of the deque
+func (s *snapshotDeque[X]) Dequeue() (X, bool) {
+	return s.PopFront()
+}
+
+// Front views the first element of the deque
+func (s *snapshotDeque[X]) Front() (X, bool) {
+	return s.version.Front()
+}
+
+// Back views the last element of the deque
+func (s *snapshotDeque[X]) Back() (X, bool) {
+	return s.version.Back()
+}
+
+// Double ended specifics
+
+// PushFront adds an element to the front of the deque
+func (s *snapshotDeque[X]) PushFront(x X) {
+	s.version = s.version.PushFront(x)
+}
+
+// PushBack adds an element to the back of the deque
+func (s *snapshotDeque[X]) PushBack(x X) {
+	s.version = s.version.PushBack(x)
+}
+
+// PopFront removes the front element from the deque
+func (s *snapshotDeque[X]) PopFront() (X, bool) {
+	x, version, ok := s.version.PopFront()
+	s.version = version
+	return x, ok
+}
+
+// PopBack removes the back element from the deque
+func (s *snapshotDeque[X]) PopBack() (X, bool) {
+	x, version, ok := s.version.PopBack()
+	s.version = version
+	return x, ok
+}
+
+// Indexed access
+
+// At views the element at the position
+func (s *snapshotDeque[X]) At(i int) (X, error) {
+	return s.version.At(i)
+}
+
+// Set replaces the element at the position
+func (s *snapshotDeque[X]) Set(i int, x X) error {
+	if i < 0 || i >= s.Size() {
+		return indexOutOfRange(i, s.Size())
+	}
+	s.rebuild(func(deque Deque[X]) {
+		_ = deque.Set(i, x)
+	})
+	return nil
+}
+
+// Insert adds an element at the position
+func (s *snapshotDeque[X]) Insert(i int, x X) error {
+	switch {
+	case i < 0 || i > s.Size():
+		return indexOutOfRange(i, s.Size())
+	case i == 0:
+		s.PushFront(x)
+	case i == s.Size():
+		s.PushBack(x)
+	default:
+		s.rebuild(func(deque Deque[X]) {
+			_ = deque.Insert(i, x)
+		})
+	}
+	return nil
+}
+
+// RemoveAt removes the element at the position
+func (s *snapshotDeque[X]) RemoveAt(i int) (X, error) {
+	switch {
+	case i < 0 || i >= s.Size():
+		var zero X
+		return zero, indexOutOfRange(i, s.Size())
+	case i == 0:
+		x, _ := s.PopFront()
+		return x, nil
+	case i == s.Size()-1:
+		x, _ := s.PopBack()
+		return x, nil
+	}
+
+	var x X
+	s.rebuild(func(deque Deque[X]) {
+		x, _ = deque.RemoveAt(i)
+	})
+	return x, nil
+}
+
+// Bulk operations
+
+// Rotate rotates the deque n steps to the back
+func (s *snapshotDeque[X]) Rotate(n int) {
+	if rotation(n, s.Size()) == 0 {
+		return
+	}
+	s.rebuild(func(deque Deque[X]) {
+		deque.Rotate(n)
+	})
+}
+
+// Swap exchanges the elements at the two positions
+func (s *snapshotDeque[X]) Swap(i, j int) error {
+	var err error
+	s.rebuild(func(deque Deque[X]) {
+		err = deque.Swap(i, j)
+	})
+	return err
+}
+
+// ExtendBack adds the elements to the back of the deque in order
+func (s *snapshotDeque[X]) ExtendBack(xs ...X) {
+	for _, x := range xs {
+		s.PushBack(x)
+	}
+}
+
+// ExtendFront adds the elements to the front of the deque one at a time
+func (s *snapshotDeque[X]) ExtendFront(xs ...X) {
+	for _, x := range xs {
+		s.PushFront(x)
+	}
+}
+
+// DrainTo moves up to max elements from the front of the deque
+// to the back of dst
+func (s *snapshotDeque[X]) DrainTo(dst Deque[X], max int) int {
+	return drain[X](s, dst, max)
+}
+
+// RemoveAll removes every occurrence of an element in the deque
+func (s *snapshotDeque[X]) RemoveAll(x X) int {
+	return s.RemoveAllFunc(equalTo(s.version.equal, x))
+}
+
+// RemoveAllFunc removes every element satisfying the predicate
+func (s *snapshotDeque[X]) RemoveAllFunc(predicate func(X) bool) int {
+	if !s.ContainsFunc(predicate) {
+		return 0
+	}
+	removed := 0
+	s.rebuild(func(deque Deque[X]) {
+		removed = deque.RemoveAllFunc(predicate)
+	})
+	return removed
+}
+
+// Others
+
+// Empty returns if the deque has at least one element
+func (s *snapshotDeque[X]) Empty() bool {
+	return s.version.Empty()
+}
+
+// Size returns the total elements in the deque
+func (s *snapshotDeque[X]) Size() int {
+	return s.version.Size()
+}
+
+// Clear removes all elements from the deque
+// The snapshots taken before are not affected.
+func (s *snapshotDeque[X]) Clear() {
+	s.version = PersistentDeque[X]{equal: s.version.equal}
+}
+
+// Contains checks if the element exists in the deque
+func (s *snapshotDeque[X]) Contains(x X) bool {
+	return s.version.Contains(x)
+}
+
+// ContainsFunc checks if an element satisfying the predicate
+// exists in the deque
+func (s *snapshotDeque[X]) ContainsFunc(predicate func(X) bool) bool {
+	return s.version.ContainsFunc(predicate)
+}
+
+// IndexFunc returns the position from the front of the first element
+// satisfying the predicate, -1 if there is none
+func (s *snapshotDeque[X]) IndexFunc(predicate func(X) bool) int {
+	return s.version.IndexFunc(predicate)
+}
+
+// Reverse reverses the deque
+func (s *snapshotDeque[X]) Reverse() {
+	// the front list reversed is the rear list and vice versa
+	s.version.front, s.version.rear = s.version.rear, s.version.front
+	s.version.frontSize, s.version.rearSize = s.version.rearSize, s.version.frontSize
+}
+
+// ToSlice returns the elements from the front to the back
+func (s *snapshotDeque[X]) ToSlice() []X {
+	return s.version.ToSlice()
+}
+
+// Iterators
+
+// All returns an iterator over the elements from the front to the back
+// The iteration walks the version taken when the iteration starts,
+// so the deque can be modified while iterating.
+func (s *snapshotDeque[X]) All() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		s.version.All()(yield)
+	}
+}
+
+// Backward returns an iterator over the elements from the back to the front
+// The iteration walks the version taken when the iteration starts.
+func (s *snapshotDeque[X]) Backward() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		s.version.Backward()(yield)
+	}
+}
+
+// Enumerate returns an iterator over the position and the element
+// from the front to the back
+// The iteration walks the version taken when the iteration starts.
+func (s *snapshotDeque[X]) Enumerate() iter.Seq2[int, X] {
+	return func(yield func(int, X) bool) {
+		s.version.Enumerate()(yield)
+	}
+}
+
+// Remove removes the first occurrence of an element in the deque
+func (s *snapshotDeque[X]) Remove(x X) bool {
+	return s.RemoveFunc(equalTo(s.version.equal, x))
+}
+
+// RemoveFunc removes the first element satisfying the predicate
+func (s *snapshotDeque[X]) RemoveFunc(predicate func(X) bool) bool {
+	i := s.IndexFunc(predicate)
+	if i < 0 {
+		return false
+	}
+	_, _ = s.RemoveAt(i)
+	return true
+}
@@ -0,0 +1,197 @@
+package collection
+
+// PriorityQueue is a queue which always serves the least element first,
+// the order being decided by the less function given to the constructor.
+// Elements with the same priority are served in no particular order.
+type PriorityQueue[X any] interface {
+	// Push adds an element to the queue
+	// The handle refers to the element until it is popped or removed.
+	Push(x X) *Handle[X]
+
+	// Pop removes the least element from the queue
+	// a zero value and a false if the queue is empty
+	Pop() (X, bool)
+
+	// Peek views the least element of the queue
+	// a zero value and a false if the queue is empty
+	Peek() (X, bool)
+
+	// Update replaces the element referred to by the handle
+	// and restores its position in the queue
+	// false if the element is no longer in the queue
+	Update(handle *Handle[X], x X) bool
+
+	// Remove removes the element referred to by the handle
+	// a zero value and a false if the element is no longer in the queue
+	Remove(handle *Handle[X]) (X, bool)
+
+	// Len returns the total number of elements in the queue
+	Len() int
+
+	// Empty returns true if the queue has zero element
+	Empty() bool
+
+	// Clear removes all elements from the queue
+	Clear()
+}
+
+// Handle refers to an element in a PriorityQueue
+type Handle[X any] struct {
+	x X
+	// index is the position in the heap, -1 once the element has left
+	index int
+}
+
+// binaryHeap implements the container/heap algorithms
+// on a typed slice so that the elements are not boxed.
+// It does not call container/heap on purpose: heap.Interface passes the
+// elements to Push and Pop as any, which allocates for every element.
+type binaryHeap[X any] struct {
+	items []*Handle[X]
+	less  func(a, b X) bool
+}
+
+// NewPriorityQueue creates a new priority queue backed by a binary heap
+// which serves the least element according to less first
+func NewPriorityQueue[X any](less func(a, b X) bool) PriorityQueue[X] {
+	return &binaryHeap[X]{less: less}
+}
+
+// Push adds an element to the queue
+func (h *binaryHeap[X]) Push(x X) *Handle[X] {
+	handle := &Handle[X]{x: x, index: len(h.items)}
+	h.items = append(h.items, handle)
+	h.up(handle.index)
+	return handle
+}
+
+// Pop removes the least element from the queue
+func (h *binaryHeap[X]) Pop() (X, bool) {
+	if len(h.items) == 0 {
+		var zero X
+		return zero, false
+	}
+	return h.remove(0), true
+}
+
+// Peek views the least element of the queue
+func (h *binaryHeap[X]) Peek() (X, bool) {
+	if len(h.items) == 0 {
+		var zero X
+		return zero, false
+	}
+	return h.items[0].x, true
+}
+
+// Update replaces the element referred to by the handle
+func (h *binaryHeap[X]) Update(handle *Handle[X], x X) bool {
+	if !h.owns(handle) {
+		return false
+	}
+	handle.x = x
+	h.fix(handle.index)
+	return true
+}
+
+// Remove removes the element referred to by the handle
+func (h *binaryHeap[X]) Remove(handle *Handle[X]) (X, bool) {
+	if !h.owns(handle) {
+		var zero X
+		return zero, false
+	}
+	return h.remove(handle.index), true
+}
+
+// Len returns the total number of elements in the queue
+func (h *binaryHeap[X]) Len() int {
+	return len(h.items)
+}
+
+// Empty returns true if the queue has zero element
+func (h *binaryHeap[X]) Empty() bool {
+	return len(h.items) == 0
+}
+
+// Clear removes all elements from the queue
+func (h *binaryHeap[X]) Clear() {
+	for _, handle := range h.items {
+		handle.index = -1
+	}
+	h.items = nil
+}
+
+// owns returns if the handle refers to an element in this heap
+func (h *binaryHeap[X]) owns(handle *Handle[X]) bool {
+	return handle != nil &&
+		handle.index >= 0 &&
+		handle.index < len(h.items) &&
+		h.items[handle.index] == handle
+}
+
+// remove removes the element at the position like heap.Remove
+func (h *binaryHeap[X]) remove(i int) X {
+	n := len(h.items) - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+
+	handle := h.items[n]
+	h.items[n] = nil
+	h.items = h.items[:n]
+	handle.index = -1
+	return handle.x
+}
+
+// fix restores the heap after the element at the position changed
+// like heap.Fix
+func (h *binaryHeap[X]) fix(i int) {
+	if !h.down(i, len(h.items)) {
+		h.up(i)
+	}
+}
+
+func (h *binaryHeap[X]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.lessAt(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+// down moves the element at i0 towards the leaves within the first n
+// elements, true if the element has moved
+func (h *binaryHeap[X]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.lessAt(j2, j1) {
+			j = j2 // right child
+		}
+		if !h.lessAt(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (h *binaryHeap[X]) lessAt(i, j int) bool {
+	return h.less(h.items[i].x, h.items[j].x)
+}
+
+func (h *binaryHeap[X]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
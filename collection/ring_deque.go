@@ -0,0 +1,442 @@
+package collection
+
+import "iter"
+
+// minRingCapacity is the smallest backing buffer a ringDeque allocates
+const minRingCapacity = 8
+
+// ringDeque is a deque backed by a circular buffer.
+// The buffer doubles when it is full and halves when it is a quarter
+// full, so the memory used is proportional to the number of elements
+// and both ends can be inserted and removed in amortized O(1).
+// Insert and RemoveAt in the middle shift O(min(i, n-i)) elements.
+type ringDeque[X any] struct {
+	buf   []X
+	head  int
+	size  int
+	equal func(a, b X) bool
+}
+
+// NewRingDeque creates a new deque backed by a circular buffer
+func NewRingDeque[X comparable]() Deque[X] {
+	return &ringDeque[X]{equal: equalComparable[X]}
+}
+
+// NewRingDequeFunc creates a new deque backed by a circular buffer
+// which compares the elements with the equality function.
+// A nil equality function falls back to the == operator.
+func NewRingDequeFunc[X any](equal func(a, b X) bool) Deque[X] {
+	return &ringDeque[X]{equal: equal}
+}
+
+// equality returns the equality function of the deque
+func (r *ringDeque[X]) equality() func(a, b X) bool {
+	return r.equal
+}
+
+// index converts a position relative to the front of the deque
+// into an index of the backing buffer
+func (r *ringDeque[X]) index(i int) int {
+	return (r.head + i) % len(r.buf)
+}
+
+// resize moves the elements into a new buffer of the given capacity
+// with the front element at index 0
+func (r *ringDeque[X]) resize(capacity int) {
+	buf := make([]X, capacity)
+	if r.size > 0 {
+		if r.head+r.size <= len(r.buf) {
+			copy(buf, r.buf[r.head:r.head+r.size])
+		} else {
+			n := copy(buf, r.buf[r.head:])
+			copy(buf[n:], r.buf[:r.size-n])
+		}
+	}
+	r.buf = buf
+	r.head = 0
+}
+
+// growIfFull doubles the buffer when there is no space for another element
+func (r *ringDeque[X]) growIfFull() {
+	if r.size < len(r.buf) {
+		return
+	}
+	r.resize(max(len(r.buf)*2, minRingCapacity))
+}
+
+// shrinkIfSparse halves the buffer when it is at most a quarter full
+func (r *ringDeque[X]) shrinkIfSparse() {
+	if len(r.buf) <= minRingCapacity || r.size > len(r.buf)/4 {
+		return
+	}
+	r.resize(len(r.buf) / 2)
+}
+
+// Stack specifics
+
+// Push adds an element to the top of the deque
+func (r *ringDeque[X]) Push(x X) {
+	r.PushBack(x)
+}
+
+// Pop removes the top element from the deque
+func (r *ringDeque[X]) Pop() (X, bool) {
+	return r.PopBack()
+}
+
+// Peek views the top element from the deque
+func (r *ringDeque[X]) Peek() (X, bool) {
+	if r.size == 0 {
+		var zero X
+		return zero, false
+	}
+	return r.buf[r.index(r.size-1)], true
+}
+
+// Top views the top element from the deque
+// This is an alias for Peek but provides semantic clarity for stack
+// use cases.
+func (r *ringDeque[X]) Top() (X, bool) {
+	return r.Peek()
+}
+
+// Queue specifics
+
+// Enqueue adds an element to the end of the deque
+func (r *ringDeque[X]) Enqueue(x X) {
+	r.PushBack(x)
+}
+
+// Dequeue removes an element from the front of the deque
+func (r *ringDeque[X]) Dequeue() (X, bool) {
+	return r.PopFront()
+}
+
+// Front views the first element of the deque
+func (r *ringDeque[X]) Front() (X, bool) {
+	if r.size == 0 {
+		var zero X
+		return zero, false
+	}
+	return r.buf[r.head], true
+}
+
+// Back views the last element of the deque
+func (r *ringDeque[X]) Back() (X, bool) {
+	return r.Peek()
+}
+
+// Double ended specifics
+
+// PushFront adds an element to the front of the deque
+func (r *ringDeque[X]) PushFront(x X) {
+	r.growIfFull()
+	r.head = (r.head - 1 + len(r.buf)) % len(r.buf)
+	r.buf[r.head] = x
+	r.size++
+}
+
+// PushBack adds an element to the back of the deque
+func (r *ringDeque[X]) PushBack(x X) {
+	r.growIfFull()
+	r.buf[r.index(r.size)] = x
+	r.size++
+}
+
+// PopFront removes the front element from the deque
+func (r *ringDeque[X]) PopFront() (X, bool) {
+	if r.size == 0 {
+		var zero X
+		return zero, false
+	}
+	x := r.buf[r.head]
+
+	// Release the reference so that it can be garbage collected
+	var zero X
+	r.buf[r.head] = zero
+	r.head = r.index(1)
+	r.size--
+	r.shrinkIfSparse()
+	return x, true
+}
+
+// PopBack removes the back element from the deque
+func (r *ringDeque[X]) PopBack() (X, bool) {
+	if r.size == 0 {
+		var zero X
+		return zero, false
+	}
+	i := r.index(r.size - 1)
+	x := r.buf[i]
+
+	// Release the reference so that it can be garbage collected
+	var zero X
+	r.buf[i] = zero
+	r.size--
+	r.shrinkIfSparse()
+	return x, true
+}
+
+// Others
+
+// Empty returns if the deque has at least one element
+func (r *ringDeque[X]) Empty() bool {
+	return r.size == 0
+}
+
+// Size returns the total elements in the deque
+func (r *ringDeque[X]) Size() int {
+	return r.size
+}
+
+// Clear removes all elements from the deque
+func (r *ringDeque[X]) Clear() {
+	r.buf = nil
+	r.head = 0
+	r.size = 0
+}
+
+// Contains checks if the element exists in the deque
+func (r *ringDeque[X]) Contains(x X) bool {
+	return r.ContainsFunc(equalTo(r.equal, x))
+}
+
+// ContainsFunc checks if an element satisfying the predicate
+// exists in the deque
+func (r *ringDeque[X]) ContainsFunc(predicate func(X) bool) bool {
+	return r.IndexFunc(predicate) >= 0
+}
+
+// IndexFunc returns the position from the front of the first element
+// satisfying the predicate, -1 if there is none
+func (r *ringDeque[X]) IndexFunc(predicate func(X) bool) int {
+	for i := 0; i < r.size; i++ {
+		if predicate(r.buf[r.index(i)]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Reverse reverses the deque
+func (r *ringDeque[X]) Reverse() {
+	for i, j := 0, r.size-1; i < j; i, j = i+1, j-1 {
+		a, b := r.index(i), r.index(j)
+		r.buf[a], r.buf[b] = r.buf[b], r.buf[a]
+	}
+}
+
+func (r *ringDeque[X]) ToSlice() []X {
+	slice := make([]X, 0, r.size)
+	for i := 0; i < r.size; i++ {
+		slice = append(slice, r.buf[r.index(i)])
+	}
+	return slice
+}
+
+// All returns an iterator over the elements from the front to the back
+func (r *ringDeque[X]) All() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(r.buf[r.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements from the back to the front
+func (r *ringDeque[X]) Backward() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for i := r.size - 1; i >= 0; i-- {
+			if !yield(r.buf[r.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate returns an iterator over the position and the element
+// from the front to the back
+func (r *ringDeque[X]) Enumerate() iter.Seq2[int, X] {
+	return func(yield func(int, X) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(i, r.buf[r.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Remove removes the first occurrence of an element in the deque
+func (r *ringDeque[X]) Remove(x X) bool {
+	return r.RemoveFunc(equalTo(r.equal, x))
+}
+
+// RemoveFunc removes the first element satisfying the predicate
+func (r *ringDeque[X]) RemoveFunc(predicate func(X) bool) bool {
+	i := r.IndexFunc(predicate)
+	if i < 0 {
+		return false
+	}
+
+	_, err := r.RemoveAt(i)
+	return err == nil
+}
+
+// At views the element at the position
+func (r *ringDeque[X]) At(i int) (X, error) {
+	if i < 0 || i >= r.size {
+		var zero X
+		return zero, indexOutOfRange(i, r.size)
+	}
+	return r.buf[r.index(i)], nil
+}
+
+// Set replaces the element at the position
+func (r *ringDeque[X]) Set(i int, x X) error {
+	if i < 0 || i >= r.size {
+		return indexOutOfRange(i, r.size)
+	}
+	r.buf[r.index(i)] = x
+	return nil
+}
+
+// Insert adds an element at the position
+// The elements on the shorter side of the position are shifted,
+// so it takes O(min(i, n-i)) time and inserting near either end is cheap.
+func (r *ringDeque[X]) Insert(i int, x X) error {
+	if i < 0 || i > r.size {
+		return indexOutOfRange(i, r.size)
+	}
+
+	r.growIfFull()
+	if i < r.size/2 {
+		// shift the elements before the position towards the front
+		r.head = (r.head - 1 + len(r.buf)) % len(r.buf)
+		for j := 0; j < i; j++ {
+			r.buf[r.index(j)] = r.buf[r.index(j+1)]
+		}
+	} else {
+		// shift the elements from the position towards the back
+		for j := r.size; j > i; j-- {
+			r.buf[r.index(j)] = r.buf[r.index(j-1)]
+		}
+	}
+	r.buf[r.index(i)] = x
+	r.size++
+	return nil
+}
+
+// RemoveAt removes the element at the position
+// The elements on the shorter side of the position are shifted,
+// so it takes O(min(i, n-i)) time and removing near either end is cheap.
+func (r *ringDeque[X]) RemoveAt(i int) (X, error) {
+	if i < 0 || i >= r.size {
+		var zero X
+		return zero, indexOutOfRange(i, r.size)
+	}
+
+	x := r.buf[r.index(i)]
+	var zero X
+	if i < r.size/2 {
+		// shift the elements before the position towards the back
+		for j := i; j > 0; j-- {
+			r.buf[r.index(j)] = r.buf[r.index(j-1)]
+		}
+		r.buf[r.head] = zero
+		r.head = r.index(1)
+	} else {
+		// shift the elements after the position towards the front
+		for j := i; j < r.size-1; j++ {
+			r.buf[r.index(j)] = r.buf[r.index(j+1)]
+		}
+		r.buf[r.index(r.size-1)] = zero
+	}
+	r.size--
+	r.shrinkIfSparse()
+	return x, nil
+}
+
+// Rotate rotates the deque n steps to the back
+// The elements on the shorter side are moved, so small rotations
+// in either direction are cheap.
+func (r *ringDeque[X]) Rotate(n int) {
+	n = rotation(n, r.size)
+	if n == 0 {
+		return
+	}
+
+	if n <= r.size/2 {
+		for i := 0; i < n; i++ {
+			x, _ := r.PopBack()
+			r.PushFront(x)
+		}
+		return
+	}
+	for i := 0; i < r.size-n; i++ {
+		x, _ := r.PopFront()
+		r.PushBack(x)
+	}
+}
+
+// Swap exchanges the elements at the two positions
+func (r *ringDeque[X]) Swap(i, j int) error {
+	if i < 0 || i >= r.size {
+		return indexOutOfRange(i, r.size)
+	}
+	if j < 0 || j >= r.size {
+		return indexOutOfRange(j, r.size)
+	}
+
+	i, j = r.index(i), r.index(j)
+	r.buf[i], r.buf[j] = r.buf[j], r.buf[i]
+	return nil
+}
+
+// ExtendBack adds the elements to the back of the deque in order
+func (r *ringDeque[X]) ExtendBack(xs ...X) {
+	for _, x := range xs {
+		r.PushBack(x)
+	}
+}
+
+// ExtendFront adds the elements to the front of the deque one at a time
+func (r *ringDeque[X]) ExtendFront(xs ...X) {
+	for _, x := range xs {
+		r.PushFront(x)
+	}
+}
+
+// DrainTo moves up to max elements from the front of the deque to dst
+func (r *ringDeque[X]) DrainTo(dst Deque[X], max int) int {
+	return drain[X](r, dst, max)
+}
+
+// RemoveAll removes every occurrence of an element in the deque
+func (r *ringDeque[X]) RemoveAll(x X) int {
+	return r.RemoveAllFunc(equalTo(r.equal, x))
+}
+
+// RemoveAllFunc removes every element satisfying the predicate
+func (r *ringDeque[X]) RemoveAllFunc(predicate func(X) bool) int {
+	// compact the kept elements towards the front
+	kept := 0
+	for i := 0; i < r.size; i++ {
+		x := r.buf[r.index(i)]
+		if !predicate(x) {
+			r.buf[r.index(kept)] = x
+			kept++
+		}
+	}
+
+	var zero X
+	for i := kept; i < r.size; i++ {
+		r.buf[r.index(i)] = zero
+	}
+
+	removed := r.size - kept
+	r.size = kept
+	r.shrinkIfSparse()
+	return removed
+}
@@ -0,0 +1,174 @@
+package collection
+
+import (
+	"iter"
+	"maps"
+)
+
+// Set is an unordered collection of unique elements
+type Set[X comparable] interface {
+	// Add adds an element to the set
+	// false if the element is already in the set
+	Add(x X) bool
+
+	// Remove removes an element from the set
+	// false if the element is not in the set
+	Remove(x X) bool
+
+	// Contains checks if the element exists in the set
+	Contains(x X) bool
+
+	// Len returns the total number of elements in the set
+	Len() int
+
+	// Empty returns true if the set has zero element
+	Empty() bool
+
+	// Clear removes all elements from the set
+	Clear()
+
+	// Set algebra, each operation returns a new set of the same kind
+
+	// Union returns the elements in either set
+	Union(other Set[X]) Set[X]
+
+	// Intersection returns the elements in both sets
+	Intersection(other Set[X]) Set[X]
+
+	// Difference returns the elements in this set but not in the other
+	Difference(other Set[X]) Set[X]
+
+	// SymmetricDifference returns the elements in exactly one of the sets
+	SymmetricDifference(other Set[X]) Set[X]
+
+	// IsSubset returns true if every element of this set is in the other
+	IsSubset(other Set[X]) bool
+
+	// All returns an iterator over the elements in no particular order
+	All() iter.Seq[X]
+
+	// ToSlice returns the elements in no particular order
+	ToSlice() []X
+}
+
+// hashSet is a set backed by a map
+type hashSet[X comparable] struct {
+	elements map[X]struct{}
+}
+
+// NewSet creates a new set backed by a map holding the elements
+func NewSet[X comparable](xs ...X) Set[X] {
+	s := &hashSet[X]{elements: make(map[X]struct{}, len(xs))}
+	for _, x := range xs {
+		s.elements[x] = struct{}{}
+	}
+	return s
+}
+
+// Add adds an element to the set
+func (s *hashSet[X]) Add(x X) bool {
+	if _, ok := s.elements[x]; ok {
+		return false
+	}
+	s.elements[x] = struct{}{}
+	return true
+}
+
+// Remove removes an element from the set
+func (s *hashSet[X]) Remove(x X) bool {
+	if _, ok := s.elements[x]; !ok {
+		return false
+	}
+	delete(s.elements, x)
+	return true
+}
+
+// Contains checks if the element exists in the set
+func (s *hashSet[X]) Contains(x X) bool {
+	_, ok := s.elements[x]
+	return ok
+}
+
+// Len returns the total number of elements in the set
+func (s *hashSet[X]) Len() int {
+	return len(s.elements)
+}
+
+// Empty returns true if the set has zero element
+func (s *hashSet[X]) Empty() bool {
+	return len(s.elements) == 0
+}
+
+// Clear removes all elements from the set
+func (s *hashSet[X]) Clear() {
+	clear(s.elements)
+}
+
+// Union returns the elements in either set
+func (s *hashSet[X]) Union(other Set[X]) Set[X] {
+	union := &hashSet[X]{elements: maps.Clone(s.elements)}
+	for x := range other.All() {
+		union.elements[x] = struct{}{}
+	}
+	return union
+}
+
+// Intersection returns the elements in both sets
+func (s *hashSet[X]) Intersection(other Set[X]) Set[X] {
+	intersection := NewSet[X]()
+	for x := range s.elements {
+		if other.Contains(x) {
+			intersection.Add(x)
+		}
+	}
+	return intersection
+}
+
+// Difference returns the elements in this set but not in the other
+func (s *hashSet[X]) Difference(other Set[X]) Set[X] {
+	difference := NewSet[X]()
+	for x := range s.elements {
+		if !other.Contains(x) {
+			difference.Add(x)
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns the elements in exactly one of the sets
+func (s *hashSet[X]) SymmetricDifference(other Set[X]) Set[X] {
+	difference := s.Difference(other)
+	for x := range other.All() {
+		if !s.Contains(x) {
+			difference.Add(x)
+		}
+	}
+	return difference
+}
+
+// IsSubset returns true if every element of this set is in the other
+func (s *hashSet[X]) IsSubset(other Set[X]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for x := range s.elements {
+		if !other.Contains(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the elements in no particular order
+func (s *hashSet[X]) All() iter.Seq[X] {
+	return maps.Keys(s.elements)
+}
+
+// ToSlice returns the elements in no particular order
+func (s *hashSet[X]) ToSlice() []X {
+	slice := make([]X, 0, len(s.elements))
+	for x := range s.elements {
+		slice = append(slice, x)
+	}
+	return slice
+}
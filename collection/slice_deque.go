@@ -1,36 +1,54 @@
 package collection
 
+import (
+	"iter"
+	"slices"
+)
+
 // sliceDeque stands for double ended queue
-type sliceDeque[X comparable] struct {
-	data []X
+type sliceDeque[X any] struct {
+	// data holds the elements in data[head:]. The space in front of head
+	// is reserved for PushFront so that inserting at the front does not
+	// shift the elements on every call.
+	data  []X
+	head  int
+	equal func(a, b X) bool
 }
 
 // NewSliceDeque creates a new deque backed by a slice
+// The backing slice is only released once the deque is emptied,
+// prefer NewRingDeque for long running FIFO use.
 func NewSliceDeque[X comparable]() Deque[X] {
-	return &sliceDeque[X]{}
+	return &sliceDeque[X]{equal: equalComparable[X]}
+}
+
+// NewSliceDequeFunc creates a new deque backed by a slice
+// which compares the elements with the equality function.
+// A nil equality function falls back to the == operator.
+func NewSliceDequeFunc[X any](equal func(a, b X) bool) Deque[X] {
+	return &sliceDeque[X]{equal: equal}
+}
+
+// equality returns the equality function of the deque
+func (s *sliceDeque[X]) equality() func(a, b X) bool {
+	return s.equal
 }
 
 // Stack specifics
 
 // Push adds an element to the top of the deque
 func (s *sliceDeque[X]) Push(x X) {
-	s.data = append(s.data, x)
+	s.PushBack(x)
 }
 
 // Pop removes the top element from the deque
 func (s *sliceDeque[X]) Pop() (X, bool) {
-	if len(s.data) == 0 {
-		var zero X
-		return zero, false
-	}
-	x := s.data[len(s.data)-1]
-	s.data = s.data[:len(s.data)-1]
-	return x, true
+	return s.PopBack()
 }
 
 // Peek views the top element from the deque
 func (s *sliceDeque[X]) Peek() (X, bool) {
-	if len(s.data) == 0 {
+	if s.Empty() {
 		var zero X
 		return zero, false
 	}
@@ -49,27 +67,21 @@ func (s *sliceDeque[X]) Top() (X, bool) {
 
 // Enqueue adds an element to the end of the deque
 func (s *sliceDeque[X]) Enqueue(x X) {
-	s.data = append(s.data, x)
+	s.PushBack(x)
 }
 
 // Dequeue removes an element from the front of the deque
 func (s *sliceDeque[X]) Dequeue() (X, bool) {
-	if len(s.data) == 0 {
-		var zero X
-		return zero, false
-	}
-	x := s.data[0]
-	s.data = s.data[1:]
-	return x, true
+	return s.PopFront()
 }
 
 // Front views the first element of the deque
 func (s *sliceDeque[X]) Front() (X, bool) {
-	if len(s.data) == 0 {
+	if s.Empty() {
 		var zero X
 		return zero, false
 	}
-	return s.data[0], true
+	return s.data[s.head], true
 }
 
 // Back views the last element of the deque
@@ -77,53 +89,297 @@ func (s *sliceDeque[X]) Back() (X, bool) {
 	return s.Peek()
 }
 
+// Double ended specifics
+
+// PushFront adds an element to the front of the deque
+func (s *sliceDeque[X]) PushFront(x X) {
+	if s.head == 0 {
+		s.growFront()
+	}
+	s.head--
+	s.data[s.head] = x
+}
+
+// growFront reallocates the backing slice with free space in front of
+// the elements. The free space is as large as the elements themselves so
+// that PushFront is amortized O(1).
+func (s *sliceDeque[X]) growFront() {
+	size := len(s.data) - s.head
+	room := max(size, 4)
+
+	data := make([]X, room+size)
+	copy(data[room:], s.data[s.head:])
+	s.data = data
+	s.head = room
+}
+
+// PushBack adds an element to the back of the deque
+func (s *sliceDeque[X]) PushBack(x X) {
+	s.data = append(s.data, x)
+}
+
+// PopFront removes the front element from the deque
+func (s *sliceDeque[X]) PopFront() (X, bool) {
+	if s.Empty() {
+		var zero X
+		return zero, false
+	}
+	x := s.data[s.head]
+
+	// Release the reference so that it can be garbage collected
+	var zero X
+	s.data[s.head] = zero
+	s.head++
+	s.resetIfEmpty()
+	return x, true
+}
+
+// PopBack removes the back element from the deque
+func (s *sliceDeque[X]) PopBack() (X, bool) {
+	if s.Empty() {
+		var zero X
+		return zero, false
+	}
+	x := s.data[len(s.data)-1]
+
+	// Release the reference so that it can be garbage collected
+	var zero X
+	s.data[len(s.data)-1] = zero
+	s.data = s.data[:len(s.data)-1]
+	s.resetIfEmpty()
+	return x, true
+}
+
+// resetIfEmpty rewinds the deque to the start of the backing slice
+// once the last element is removed
+func (s *sliceDeque[X]) resetIfEmpty() {
+	if s.Empty() {
+		s.data = s.data[:0]
+		s.head = 0
+	}
+}
+
 // Others
 
 // Empty returns if the deque has at least one element
 func (s *sliceDeque[X]) Empty() bool {
-	return len(s.data) == 0
+	return s.Size() == 0
 }
 
 // Size returns the total elements in the deque
 func (s *sliceDeque[X]) Size() int {
-	return len(s.data)
+	return len(s.data) - s.head
 }
 
 // Clear removes all elements from the deque
 func (s *sliceDeque[X]) Clear() {
 	s.data = nil
+	s.head = 0
 }
 
 // Contains checks if the element exists in the deque
 func (s *sliceDeque[X]) Contains(x X) bool {
-	for _, v := range s.data {
-		if v == x {
-			return true
+	return s.ContainsFunc(equalTo(s.equal, x))
+}
+
+// ContainsFunc checks if an element satisfying the predicate
+// exists in the deque
+func (s *sliceDeque[X]) ContainsFunc(predicate func(X) bool) bool {
+	return s.IndexFunc(predicate) >= 0
+}
+
+// IndexFunc returns the position from the front of the first element
+// satisfying the predicate, -1 if there is none
+func (s *sliceDeque[X]) IndexFunc(predicate func(X) bool) int {
+	for i, v := range s.data[s.head:] {
+		if predicate(v) {
+			return i
 		}
 	}
-	return false
+	return -1
 }
 
 // Reverse reverses the deque
 func (s *sliceDeque[X]) Reverse() {
-	for i, j := 0, len(s.data)-1; i < j; i, j = i+1, j-1 {
+	for i, j := s.head, len(s.data)-1; i < j; i, j = i+1, j-1 {
 		s.data[i], s.data[j] = s.data[j], s.data[i]
 	}
 }
 
 func (s *sliceDeque[X]) ToSlice() []X {
 	// Return a copy to prevent mutation
-	return append([]X{}, s.data...)
+	return append([]X{}, s.data[s.head:]...)
+}
+
+// All returns an iterator over the elements from the front to the back
+func (s *sliceDeque[X]) All() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for i := s.head; i < len(s.data); i++ {
+			if !yield(s.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the elements from the back to the front
+func (s *sliceDeque[X]) Backward() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for i := len(s.data) - 1; i >= s.head; i-- {
+			if !yield(s.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate returns an iterator over the position and the element
+// from the front to the back
+func (s *sliceDeque[X]) Enumerate() iter.Seq2[int, X] {
+	return func(yield func(int, X) bool) {
+		for i := s.head; i < len(s.data); i++ {
+			if !yield(i-s.head, s.data[i]) {
+				return
+			}
+		}
+	}
 }
 
 // Remove removes the first occurrence of an element in the deque
 func (s *sliceDeque[X]) Remove(x X) bool {
-	for i, v := range s.data {
-		if v == x {
-			s.data = append(s.data[:i], s.data[i+1:]...)
-			return true
-		}
+	return s.RemoveFunc(equalTo(s.equal, x))
+}
+
+// RemoveFunc removes the first element satisfying the predicate
+func (s *sliceDeque[X]) RemoveFunc(predicate func(X) bool) bool {
+	i := s.IndexFunc(predicate)
+	if i < 0 {
+		return false
+	}
+
+	_, err := s.RemoveAt(i)
+	return err == nil
+}
+
+// At views the element at the position
+func (s *sliceDeque[X]) At(i int) (X, error) {
+	if i < 0 || i >= s.Size() {
+		var zero X
+		return zero, indexOutOfRange(i, s.Size())
+	}
+	return s.data[s.head+i], nil
+}
+
+// Set replaces the element at the position
+func (s *sliceDeque[X]) Set(i int, x X) error {
+	if i < 0 || i >= s.Size() {
+		return indexOutOfRange(i, s.Size())
+	}
+	s.data[s.head+i] = x
+	return nil
+}
+
+// Insert adds an element at the position
+// The elements after the position are shifted, so it takes O(n-i) time
+// except at the front which takes amortized O(1).
+func (s *sliceDeque[X]) Insert(i int, x X) error {
+	if i < 0 || i > s.Size() {
+		return indexOutOfRange(i, s.Size())
+	}
+	if i == 0 {
+		s.PushFront(x)
+		return nil
+	}
+
+	// make room at the back then shift the elements after the position
+	var zero X
+	s.data = append(s.data, zero)
+	i += s.head
+	copy(s.data[i+1:], s.data[i:])
+	s.data[i] = x
+	return nil
+}
+
+// RemoveAt removes the element at the position
+// The elements after the position are shifted, so it takes O(n-i) time
+// except at the front which takes O(1).
+func (s *sliceDeque[X]) RemoveAt(i int) (X, error) {
+	if i < 0 || i >= s.Size() {
+		var zero X
+		return zero, indexOutOfRange(i, s.Size())
+	}
+	if i == 0 {
+		x, _ := s.PopFront()
+		return x, nil
+	}
+
+	i += s.head
+	x := s.data[i]
+	copy(s.data[i:], s.data[i+1:])
+
+	var zero X
+	s.data[len(s.data)-1] = zero
+	s.data = s.data[:len(s.data)-1]
+	s.resetIfEmpty()
+	return x, nil
+}
+
+// Rotate rotates the deque n steps to the back
+func (s *sliceDeque[X]) Rotate(n int) {
+	n = rotation(n, s.Size())
+	if n == 0 {
+		return
+	}
+
+	// rotating is reversing the whole then both parts
+	elements := s.data[s.head:]
+	slices.Reverse(elements)
+	slices.Reverse(elements[:n])
+	slices.Reverse(elements[n:])
+}
+
+// Swap exchanges the elements at the two positions
+func (s *sliceDeque[X]) Swap(i, j int) error {
+	if i < 0 || i >= s.Size() {
+		return indexOutOfRange(i, s.Size())
+	}
+	if j < 0 || j >= s.Size() {
+		return indexOutOfRange(j, s.Size())
 	}
 
-	return false
+	i, j = s.head+i, s.head+j
+	s.data[i], s.data[j] = s.data[j], s.data[i]
+	return nil
+}
+
+// ExtendBack adds the elements to the back of the deque in order
+func (s *sliceDeque[X]) ExtendBack(xs ...X) {
+	s.data = append(s.data, xs...)
+}
+
+// ExtendFront adds the elements to the front of the deque one at a time
+func (s *sliceDeque[X]) ExtendFront(xs ...X) {
+	for _, x := range xs {
+		s.PushFront(x)
+	}
+}
+
+// DrainTo moves up to max elements from the front of the deque to dst
+func (s *sliceDeque[X]) DrainTo(dst Deque[X], max int) int {
+	return drain[X](s, dst, max)
+}
+
+// RemoveAll removes every occurrence of an element in the deque
+func (s *sliceDeque[X]) RemoveAll(x X) int {
+	return s.RemoveAllFunc(equalTo(s.equal, x))
+}
+
+// RemoveAllFunc removes every element satisfying the predicate
+func (s *sliceDeque[X]) RemoveAllFunc(predicate func(X) bool) int {
+	// DeleteFunc clears the elements left behind at the end
+	size := s.Size()
+	kept := slices.DeleteFunc(s.data[s.head:], predicate)
+	s.data = s.data[:s.head+len(kept)]
+	s.resetIfEmpty()
+	return size - len(kept)
 }
@@ -0,0 +1,338 @@
+package collection
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+)
+
+// SortedMap is a map which keeps its keys sorted by a comparator
+type SortedMap[K any, V any] interface {
+	// Get returns the value of the key
+	// a zero value and a false if the key is not in the map
+	Get(key K) (V, bool)
+
+	// Set sets the value of the key
+	Set(key K, value V)
+
+	// Delete removes the key from the map
+	// false if the key is not in the map
+	Delete(key K) bool
+
+	// Contains checks if the key exists in the map
+	Contains(key K) bool
+
+	// Len returns the total number of keys in the map
+	Len() int
+
+	// Empty returns true if the map has zero key
+	Empty() bool
+
+	// Clear removes all keys from the map
+	Clear()
+
+	// Min views the least key and its value
+	// zero values and a false if the map is empty
+	Min() (K, V, bool)
+
+	// Max views the greatest key and its value
+	// zero values and a false if the map is empty
+	Max() (K, V, bool)
+
+	// PopMin removes the least key and returns it with its value
+	// zero values and a false if the map is empty
+	PopMin() (K, V, bool)
+
+	// PopMax removes the greatest key and returns it with its value
+	// zero values and a false if the map is empty
+	PopMax() (K, V, bool)
+
+	// Floor views the greatest key less than or equal to the key
+	// zero values and a false if there is none
+	Floor(key K) (K, V, bool)
+
+	// Ceiling views the least key greater than or equal to the key
+	// zero values and a false if there is none
+	Ceiling(key K) (K, V, bool)
+
+	// Range returns an iterator over the keys from "from" included
+	// to "to" excluded in ascending order
+	Range(from, to K) iter.Seq2[K, V]
+
+	// All returns an iterator over the keys in ascending order
+	All() iter.Seq2[K, V]
+
+	// Backward returns an iterator over the keys in descending order
+	Backward() iter.Seq2[K, V]
+}
+
+// skipListMaxLevel allows billions of keys with a promotion
+// probability of 1/4
+const skipListMaxLevel = 16
+
+type skipNode[K any, V any] struct {
+	key   K
+	value V
+	// next holds the following node on every level of this node,
+	// a removed node keeps its links so that iterators can move on
+	next []*skipNode[K, V]
+	prev *skipNode[K, V]
+}
+
+// skipList is a probabilistic balanced structure: every node is in the
+// bottom level list, and each upper level skips about 3 out of 4 nodes
+// of the level below, giving logarithmic searches on average.
+type skipList[K any, V any] struct {
+	head    *skipNode[K, V]
+	tail    *skipNode[K, V]
+	level   int
+	size    int
+	compare func(a, b K) int
+	// update is reused by the searches which modify the list
+	update [skipListMaxLevel]*skipNode[K, V]
+}
+
+// NewSortedMap creates a new sorted map backed by a skip list
+// ordering the keys naturally
+func NewSortedMap[K cmp.Ordered, V any]() SortedMap[K, V] {
+	return NewSortedMapFunc[K, V](cmp.Compare[K])
+}
+
+// NewSortedMapFunc creates a new sorted map backed by a skip list
+// ordering the keys with the comparator, which returns a negative number
+// when a < b, a positive number when a > b and zero when a == b.
+func NewSortedMapFunc[K any, V any](compare func(a, b K) int) SortedMap[K, V] {
+	return &skipList[K, V]{
+		head:    &skipNode[K, V]{next: make([]*skipNode[K, V], skipListMaxLevel)},
+		level:   1,
+		compare: compare,
+	}
+}
+
+// search returns the first node whose key is not less than the key,
+// nil if there is none. The last node before it on every level is
+// recorded in update unless update is nil.
+func (s *skipList[K, V]) search(key K, update []*skipNode[K, V]) *skipNode[K, V] {
+	node := s.head
+	for i := s.level - 1; i >= 0; i-- {
+		for next := node.next[i]; next != nil && s.compare(next.key, key) < 0; next = node.next[i] {
+			node = next
+		}
+		if update != nil {
+			update[i] = node
+		}
+	}
+	return node.next[0]
+}
+
+// find returns the node of the key, nil if the key is not in the list
+func (s *skipList[K, V]) find(key K) *skipNode[K, V] {
+	node := s.search(key, nil)
+	if node == nil || s.compare(node.key, key) != 0 {
+		return nil
+	}
+	return node
+}
+
+func randomLevel() int {
+	level := 1
+	for level < skipListMaxLevel && rand.Uint32()&3 == 0 {
+		level++
+	}
+	return level
+}
+
+// Get returns the value of the key
+func (s *skipList[K, V]) Get(key K) (V, bool) {
+	node := s.find(key)
+	if node == nil {
+		var zero V
+		return zero, false
+	}
+	return node.value, true
+}
+
+// Set sets the value of the key
+func (s *skipList[K, V]) Set(key K, value V) {
+	update := s.update[:]
+	node := s.search(key, update)
+	if node != nil && s.compare(node.key, key) == 0 {
+		node.value = value
+		return
+	}
+
+	level := randomLevel()
+	for ; s.level < level; s.level++ {
+		update[s.level] = s.head
+	}
+
+	node = &skipNode[K, V]{key: key, value: value, next: make([]*skipNode[K, V], level)}
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+
+	if update[0] != s.head {
+		node.prev = update[0]
+	}
+	if node.next[0] != nil {
+		node.next[0].prev = node
+	} else {
+		s.tail = node
+	}
+	s.size += 1
+}
+
+// Delete removes the key from the map
+func (s *skipList[K, V]) Delete(key K) bool {
+	update := s.update[:]
+	node := s.search(key, update)
+	if node == nil || s.compare(node.key, key) != 0 {
+		return false
+	}
+	s.unlink(node, update)
+	return true
+}
+
+// unlink removes the node given the last node before it on every level
+func (s *skipList[K, V]) unlink(node *skipNode[K, V], update []*skipNode[K, V]) {
+	for i := range node.next {
+		update[i].next[i] = node.next[i]
+	}
+
+	if node.next[0] != nil {
+		node.next[0].prev = node.prev
+	} else {
+		s.tail = node.prev
+	}
+
+	for s.level > 1 && s.head.next[s.level-1] == nil {
+		s.level--
+	}
+	s.size -= 1
+}
+
+// Contains checks if the key exists in the map
+func (s *skipList[K, V]) Contains(key K) bool {
+	return s.find(key) != nil
+}
+
+// Len returns the total number of keys in the map
+func (s *skipList[K, V]) Len() int {
+	return s.size
+}
+
+// Empty returns true if the map has zero key
+func (s *skipList[K, V]) Empty() bool {
+	return s.size == 0
+}
+
+// Clear removes all keys from the map
+func (s *skipList[K, V]) Clear() {
+	clear(s.head.next)
+	s.tail = nil
+	s.level = 1
+	s.size = 0
+}
+
+// Min views the least key and its value
+func (s *skipList[K, V]) Min() (K, V, bool) {
+	return entryOf(s.head.next[0])
+}
+
+// Max views the greatest key and its value
+func (s *skipList[K, V]) Max() (K, V, bool) {
+	return entryOf(s.tail)
+}
+
+// PopMin removes the least key and returns it with its value
+func (s *skipList[K, V]) PopMin() (K, V, bool) {
+	node := s.head.next[0]
+	if node == nil {
+		return entryOf(node)
+	}
+
+	// the head is right before the least node on every level
+	update := s.update[:len(node.next)]
+	for i := range update {
+		update[i] = s.head
+	}
+	s.unlink(node, update)
+	return entryOf(node)
+}
+
+// PopMax removes the greatest key and returns it with its value
+func (s *skipList[K, V]) PopMax() (K, V, bool) {
+	node := s.tail
+	if node == nil {
+		return entryOf(node)
+	}
+
+	update := s.update[:]
+	s.search(node.key, update)
+	s.unlink(node, update)
+	return entryOf(node)
+}
+
+// Floor views the greatest key less than or equal to the key
+func (s *skipList[K, V]) Floor(key K) (K, V, bool) {
+	node := s.search(key, nil)
+	switch {
+	case node == nil:
+		// every key is less than the key
+		node = s.tail
+	case s.compare(node.key, key) != 0:
+		node = node.prev
+	}
+	return entryOf(node)
+}
+
+// Ceiling views the least key greater than or equal to the key
+func (s *skipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return entryOf(s.search(key, nil))
+}
+
+// Range returns an iterator over the keys from "from" included
+// to "to" excluded in ascending order
+func (s *skipList[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := s.search(from, nil); node != nil && s.compare(node.key, to) < 0; node = node.next[0] {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys in ascending order
+func (s *skipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := s.head.next[0]; node != nil; node = node.next[0] {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the keys in descending order
+func (s *skipList[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := s.tail; node != nil; node = node.prev {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// entryOf returns the key and the value of the node
+// zero values and a false if the node is nil
+func entryOf[K any, V any](node *skipNode[K, V]) (K, V, bool) {
+	if node == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return node.key, node.value, true
+}
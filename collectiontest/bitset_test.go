@@ -0,0 +1,115 @@
+package collectiontest
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// bitSetOf creates a bit set with the bits set
+func bitSetOf(bits ...int) *collection.BitSet {
+	b := &collection.BitSet{}
+	for _, i := range bits {
+		b.Set(i)
+	}
+	return b
+}
+
+func TestBitSet(t *testing.T) {
+	t.Run("Set, Clear, Test and Flip", func(t *testing.T) {
+		b := collection.NewBitSet(10)
+		require.True(t, b.Empty(), "a new bit set should be empty")
+		require.False(t, b.Test(1000), "bits beyond the size should be clear")
+
+		b.Set(3)
+		b.Set(200)
+		require.True(t, b.Test(3))
+		require.True(t, b.Test(200), "Set should grow the bit set")
+		require.False(t, b.Test(4))
+		require.Equal(t, 2, b.Count())
+
+		b.Clear(3)
+		b.Clear(5000)
+		require.False(t, b.Test(3))
+		b.Flip(64)
+		b.Flip(200)
+		require.Equal(t, []int{64}, slices.Collect(b.All()))
+
+		b.ClearAll()
+		require.True(t, b.Empty())
+		require.False(t, b.Test(64), "ClearAll should clear every bit")
+		b.Set(1)
+		require.False(t, b.Test(64), "growing after ClearAll should not restore bits")
+
+		require.Panics(t, func() { b.Set(-1) }, "negative bits should panic")
+	})
+
+	t.Run("NextSet should find the next bit across words", func(t *testing.T) {
+		b := bitSetOf(0, 63, 64, 130)
+		for _, testCase := range []struct {
+			from     int
+			expected int
+			ok       bool
+		}{
+			{0, 0, true},
+			{1, 63, true},
+			{64, 64, true},
+			{65, 130, true},
+			{131, -1, false},
+			{1000, -1, false},
+		} {
+			actual, ok := b.NextSet(testCase.from)
+			require.Equal(t, testCase.ok, ok, "NextSet(%d)", testCase.from)
+			require.Equal(t, testCase.expected, actual, "NextSet(%d)", testCase.from)
+		}
+	})
+
+	t.Run("set algebra should return new bit sets", func(t *testing.T) {
+		a := bitSetOf(1, 2, 100)
+		b := bitSetOf(2, 3, 200)
+
+		require.Equal(t, []int{2}, slices.Collect(a.And(b).All()))
+		require.Equal(t, []int{1, 2, 3, 100, 200}, slices.Collect(a.Or(b).All()))
+		require.Equal(t, []int{1, 3, 100, 200}, slices.Collect(a.Xor(b).All()))
+		require.Equal(t, []int{1, 100}, slices.Collect(a.AndNot(b).All()))
+		require.Equal(t, []int{3, 200}, slices.Collect(b.AndNot(a).All()))
+		require.True(t, a.Equal(bitSetOf(1, 2, 100)), "operands should not be modified")
+	})
+
+	t.Run("Equal should ignore the capacity", func(t *testing.T) {
+		a := bitSetOf(1, 500)
+		a.Clear(500)
+		require.True(t, a.Equal(bitSetOf(1)))
+		require.False(t, a.Equal(bitSetOf(2)))
+
+		clone := a.Clone()
+		clone.Set(2)
+		require.False(t, a.Test(2), "Clone should copy the bits")
+	})
+
+	t.Run("binary encoding should round trip", func(t *testing.T) {
+		random := rand.New(rand.NewPCG(1, 2))
+		b := &collection.BitSet{}
+		for i := 0; i < 100; i++ {
+			b.Set(random.IntN(1000))
+		}
+		b.Set(2000)
+		b.Clear(2000)
+
+		data, err := b.MarshalBinary()
+		require.NoError(t, err)
+		require.Zero(t, len(data)%8)
+		require.LessOrEqual(t, len(data), 1000/8+8, "trailing zero words should not be encoded")
+
+		decoded := bitSetOf(5000)
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		require.True(t, b.Equal(decoded))
+		require.Equal(t, slices.Collect(b.All()), slices.Collect(decoded.All()))
+
+		require.Error(t, decoded.UnmarshalBinary([]byte{1, 2, 3}), "partial words should be rejected")
+	})
+}
@@ -0,0 +1,37 @@
+package collectiontest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestBoundedDeque(t *testing.T) {
+	RunBoundedDequeTests(t, collection.NewBoundedDeque[int])
+
+	t.Run("non-positive capacity should panic", func(t *testing.T) {
+		require.Panics(t, func() {
+			collection.NewBoundedDeque[int](0, collection.OverflowReject)
+		})
+	})
+}
+
+func TestBoundedDequeOf(t *testing.T) {
+	RunIncomparableDequeTests(t, func(equal func(a, b []int) bool) collection.Deque[[]int] {
+		return collection.NewBoundedDequeOf(collection.NewLinkedDequeFunc(equal), 10, collection.OverflowReject)
+	})
+}
+
+func TestBoundedLinkedDeque(t *testing.T) {
+	RunBoundedDequeTests(t, collection.NewBoundedLinkedDeque[int])
+}
+
+func TestBoundedSliceDeque(t *testing.T) {
+	RunBoundedDequeTests(t, collection.NewBoundedSliceDeque[int])
+}
+
+func TestBoundedRingDeque(t *testing.T) {
+	RunBoundedDequeTests(t, collection.NewBoundedRingDeque[int])
+}
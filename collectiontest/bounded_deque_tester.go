@@ -0,0 +1,215 @@
+package collectiontest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func RunBoundedDequeTests(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	// A bounded deque with plenty of room should behave as a regular deque
+	RunDequeTests(t, func() collection.Deque[int] {
+		return newDequeFunc(1000, collection.OverflowReject)
+	})
+
+	testCases := []struct {
+		name string
+		test func(
+			t *testing.T,
+			newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+		)
+	}{
+		{"testBoundedDeque_Capacity", testBoundedDeque_Capacity},
+		{"testBoundedDeque_Reject", testBoundedDeque_Reject},
+		{"testBoundedDeque_DropOldest", testBoundedDeque_DropOldest},
+		{"testBoundedDeque_DropNewest", testBoundedDeque_DropNewest},
+		{"testBoundedDeque_Insert", testBoundedDeque_Insert},
+		{"testBoundedDeque_Extend", testBoundedDeque_Extend},
+		{"testBoundedDeque_DrainTo", testBoundedDeque_DrainTo},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.test(t, newDequeFunc)
+		})
+	}
+}
+
+func testBoundedDeque_Capacity(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	deque := newDequeFunc(3, collection.OverflowReject)
+	require.Equal(t, 3, deque.Capacity())
+	require.False(t, deque.Full(), "an empty deque should not be full")
+
+	for i := 0; i < 3; i++ {
+		deque.Push(i)
+	}
+	require.True(t, deque.Full(), "deque should be full after reaching its capacity")
+
+	deque.Pop()
+	require.False(t, deque.Full(), "deque should not be full after removing an element")
+}
+
+func testBoundedDeque_Reject(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	deque := newDequeFunc(3, collection.OverflowReject)
+	for i := 0; i < 3; i++ {
+		require.True(t, deque.OfferBack(i), "OfferBack should accept elements while not full")
+	}
+
+	require.False(t, deque.OfferBack(3), "OfferBack should reject elements when full")
+	require.False(t, deque.OfferFront(-1), "OfferFront should reject elements when full")
+
+	deque.Push(4)
+	deque.PushFront(-2)
+	deque.Enqueue(5)
+	require.Equal(t, []int{0, 1, 2}, deque.ToSlice(), "a full deque should be unchanged")
+}
+
+func testBoundedDeque_DropOldest(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	t.Run("pushing to the back should evict the front", func(t *testing.T) {
+		deque := newDequeFunc(3, collection.OverflowDropOldest)
+		for i := 0; i < 5; i++ {
+			require.True(t, deque.OfferBack(i), "OfferBack should always accept elements")
+		}
+		require.Equal(t, []int{2, 3, 4}, deque.ToSlice())
+		require.Equal(t, 3, deque.Size(), "deque size should not exceed its capacity")
+	})
+
+	t.Run("pushing to the front should evict the back", func(t *testing.T) {
+		deque := newDequeFunc(3, collection.OverflowDropOldest)
+		for i := 0; i < 5; i++ {
+			require.True(t, deque.OfferFront(i), "OfferFront should always accept elements")
+		}
+		require.Equal(t, []int{4, 3, 2}, deque.ToSlice())
+		require.Equal(t, 3, deque.Size(), "deque size should not exceed its capacity")
+	})
+}
+
+func testBoundedDeque_DropNewest(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	t.Run("pushing to the back should evict the back", func(t *testing.T) {
+		deque := newDequeFunc(3, collection.OverflowDropNewest)
+		for i := 0; i < 5; i++ {
+			require.True(t, deque.OfferBack(i), "OfferBack should always accept elements")
+		}
+		require.Equal(t, []int{0, 1, 4}, deque.ToSlice())
+		require.Equal(t, 3, deque.Size(), "deque size should not exceed its capacity")
+	})
+
+	t.Run("pushing to the front should evict the front", func(t *testing.T) {
+		deque := newDequeFunc(3, collection.OverflowDropNewest)
+		for i := 0; i < 5; i++ {
+			require.True(t, deque.OfferFront(i), "OfferFront should always accept elements")
+		}
+		require.Equal(t, []int{4, 1, 0}, deque.ToSlice())
+		require.Equal(t, 3, deque.Size(), "deque size should not exceed its capacity")
+	})
+}
+
+func testBoundedDeque_Insert(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	for _, testCase := range []struct {
+		name     string
+		policy   collection.OverflowPolicy
+		err      error
+		expected []int
+	}{
+		{"reject should fail", collection.OverflowReject, collection.ErrDequeFull, []int{0, 1, 2}},
+		{"drop oldest should evict the front", collection.OverflowDropOldest, nil, []int{1, 9, 2}},
+		{"drop newest should evict the back", collection.OverflowDropNewest, nil, []int{0, 1, 9}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			deque := newDequeFunc(3, testCase.policy)
+			for i := 0; i < 3; i++ {
+				deque.PushBack(i)
+			}
+
+			err := deque.Insert(2, 9)
+			if testCase.err != nil {
+				require.ErrorIs(t, err, testCase.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.expected, deque.ToSlice())
+
+			require.ErrorIs(t, deque.Insert(4, 9), collection.ErrIndexOutOfRange)
+			require.Equal(t, testCase.expected, deque.ToSlice(), "a failed Insert should not evict")
+		})
+	}
+}
+
+func testBoundedDeque_Extend(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	for _, testCase := range []struct {
+		name          string
+		policy        collection.OverflowPolicy
+		expectedBack  []int
+		expectedFront []int
+	}{
+		{"reject should keep the first elements", collection.OverflowReject, []int{0, 1, 2}, []int{2, 1, 0}},
+		{"drop oldest should keep the last elements", collection.OverflowDropOldest, []int{2, 3, 4}, []int{4, 3, 2}},
+		{"drop newest should replace the end", collection.OverflowDropNewest, []int{0, 1, 4}, []int{4, 1, 0}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			deque := newDequeFunc(3, testCase.policy)
+			deque.ExtendBack(0, 1, 2, 3, 4)
+			require.Equal(t, testCase.expectedBack, deque.ToSlice())
+
+			deque = newDequeFunc(3, testCase.policy)
+			deque.ExtendFront(0, 1, 2, 3, 4)
+			require.Equal(t, testCase.expectedFront, deque.ToSlice())
+		})
+	}
+}
+
+func testBoundedDeque_DrainTo(
+	t *testing.T,
+	newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int],
+) {
+	for _, testCase := range []struct {
+		name        string
+		policy      collection.OverflowPolicy
+		expected    int
+		expectedSrc []int
+		expectedDst []int
+	}{
+		{"reject should leave the rejected elements in the source", collection.OverflowReject, 2, []int{2, 3, 4}, []int{0, 1}},
+		{"drop oldest should move every element", collection.OverflowDropOldest, 5, []int{}, []int{3, 4}},
+		{"drop newest should move every element", collection.OverflowDropNewest, 5, []int{}, []int{0, 4}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			src, dst := newDequeFunc(10, collection.OverflowReject), newDequeFunc(2, testCase.policy)
+			src.ExtendBack(0, 1, 2, 3, 4)
+
+			require.Equal(t, testCase.expected, src.DrainTo(dst, -1))
+			require.Equal(t, testCase.expectedSrc, src.ToSlice())
+			require.Equal(t, testCase.expectedDst, dst.ToSlice())
+		})
+	}
+
+	t.Run("draining a full deque into itself should rotate it", func(t *testing.T) {
+		deque := newDequeFunc(3, collection.OverflowReject)
+		deque.ExtendBack(0, 1, 2)
+		require.Equal(t, 2, deque.DrainTo(deque, 2))
+		require.Equal(t, []int{2, 0, 1}, deque.ToSlice())
+	})
+}
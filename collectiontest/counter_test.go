@@ -0,0 +1,58 @@
+package collectiontest
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestCounter(t *testing.T) {
+	entry := func(x string, count int) collection.CounterEntry[string] {
+		return collection.CounterEntry[string]{Element: x, Count: count}
+	}
+
+	t.Run("Add, Count and Total", func(t *testing.T) {
+		c := collection.NewCounter("a", "b", "a")
+		require.Equal(t, 2, c.Count("a"))
+		require.Equal(t, 1, c.Count("b"))
+		require.Zero(t, c.Count("c"))
+		require.Equal(t, 3, c.Total())
+		require.Equal(t, 2, c.Len())
+
+		require.Equal(t, 3, c.Add("a"))
+		require.Equal(t, 5, c.AddN("c", 5))
+		require.Equal(t, 0, c.AddN("b", -2), "counts should not drop below zero")
+		require.Zero(t, c.Count("b"))
+		require.Equal(t, 8, c.Total())
+		require.Equal(t, map[string]int{"a": 3, "c": 5}, maps.Collect(c.All()))
+
+		require.Equal(t, 5, c.Delete("c"))
+		require.Equal(t, 3, c.Total())
+		c.Clear()
+		require.True(t, c.Empty())
+		require.Zero(t, c.Total())
+	})
+
+	t.Run("MostCommon should order by count then first counted", func(t *testing.T) {
+		c := collection.NewCounter("x", "y", "z", "y", "z", "w", "z")
+		require.Equal(t, []collection.CounterEntry[string]{entry("z", 3), entry("y", 2)}, c.MostCommon(2))
+		require.Equal(t, []collection.CounterEntry[string]{entry("z", 3), entry("y", 2), entry("x", 1), entry("w", 1)}, c.MostCommon(-1))
+		require.Len(t, c.MostCommon(10), 4)
+		require.Empty(t, c.MostCommon(0))
+	})
+
+	t.Run("arithmetic should return new counters", func(t *testing.T) {
+		a := collection.NewCounter("a", "a", "a", "b")
+		b := collection.NewCounter("a", "b", "b", "c")
+
+		require.Equal(t, map[string]int{"a": 4, "b": 3, "c": 1}, maps.Collect(a.Plus(b).All()))
+		require.Equal(t, map[string]int{"a": 2}, maps.Collect(a.Minus(b).All()), "Minus should keep positive counts")
+		require.Equal(t, map[string]int{"a": 3, "b": 2, "c": 1}, maps.Collect(a.Union(b).All()))
+		require.Equal(t, map[string]int{"a": 1, "b": 1}, maps.Collect(a.Intersection(b).All()))
+		require.Equal(t, 8, a.Plus(b).Total())
+		require.Equal(t, 4, a.Total(), "operands should not be modified")
+	})
+}
@@ -1,6 +1,8 @@
 package collectiontest
 
 import (
+	"math/rand/v2"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,6 +29,27 @@ func RunDequeTests(t *testing.T, newDequeFunc func() collection.Deque[int]) {
 		{"testDeque_Back", testDeque_Back},
 		{"testDeque_Size", testDeque_Size},
 		{"testDeque_Remove", testDeque_Remove},
+		{"testDeque_PushFront", testDeque_PushFront},
+		{"testDeque_PushBack", testDeque_PushBack},
+		{"testDeque_PopFront", testDeque_PopFront},
+		{"testDeque_PopBack", testDeque_PopBack},
+		{"testDeque_BothEnds", testDeque_BothEnds},
+		{"testDeque_All", testDeque_All},
+		{"testDeque_Backward", testDeque_Backward},
+		{"testDeque_Enumerate", testDeque_Enumerate},
+		{"testDeque_ContainsFunc", testDeque_ContainsFunc},
+		{"testDeque_IndexFunc", testDeque_IndexFunc},
+		{"testDeque_RemoveFunc", testDeque_RemoveFunc},
+		{"testDeque_At", testDeque_At},
+		{"testDeque_Set", testDeque_Set},
+		{"testDeque_Insert", testDeque_Insert},
+		{"testDeque_RemoveAt", testDeque_RemoveAt},
+		{"testDeque_IndexedModel", testDeque_IndexedModel},
+		{"testDeque_Rotate", testDeque_Rotate},
+		{"testDeque_Swap", testDeque_Swap},
+		{"testDeque_Extend", testDeque_Extend},
+		{"testDeque_DrainTo", testDeque_DrainTo},
+		{"testDeque_RemoveAll", testDeque_RemoveAll},
 	}
 
 	for _, testCase := range testCases {
@@ -338,3 +361,594 @@ func testDeque_Remove(t *testing.T, newDequeFunc func() collection.Deque[int]) {
 		require.False(t, ok, "Remove should return false")
 	})
 }
+
+func testDeque_PushFront(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("PushFront to an empty deque should be the front and the back", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.PushFront(1)
+
+		require.Equal(t, 1, deque.Size(), "deque size should be 1 after one PushFront")
+		frontVal, ok := deque.Front()
+		require.True(t, ok, "Front should return true for non-empty deque")
+		require.Equal(t, 1, frontVal, "Front should return the value pushed to the front")
+		backVal, ok := deque.Back()
+		require.True(t, ok, "Back should return true for non-empty deque")
+		require.Equal(t, 1, backVal, "Back should return the only value")
+	})
+
+	t.Run("PushFront should insert before existing elements", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.PushBack(2)
+		deque.PushFront(1)
+		deque.PushFront(0)
+
+		require.Equal(t, []int{0, 1, 2}, deque.ToSlice())
+		frontVal, ok := deque.Front()
+		require.True(t, ok, "Front should return true for non-empty deque")
+		require.Equal(t, 0, frontVal, "Front should return the last value pushed to the front")
+	})
+
+	t.Run("PushFront many elements should keep the order", func(t *testing.T) {
+		deque := newDequeFunc()
+		expected := []int{}
+		for i := 99; i >= 0; i-- {
+			deque.PushFront(i)
+		}
+		for i := 0; i < 100; i++ {
+			expected = append(expected, i)
+		}
+
+		require.Equal(t, 100, deque.Size(), "deque size should be 100")
+		require.Equal(t, expected, deque.ToSlice())
+	})
+}
+
+func testDeque_PushBack(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	deque.PushBack(1)
+	deque.PushBack(2)
+
+	require.Equal(t, []int{1, 2}, deque.ToSlice())
+	backVal, ok := deque.Back()
+	require.True(t, ok, "Back should return true for non-empty deque")
+	require.Equal(t, 2, backVal, "Back should return the last value pushed to the back")
+}
+
+func testDeque_PopFront(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("PopFront from an empty deque should be false", func(t *testing.T) {
+		deque := newDequeFunc()
+		actual, ok := deque.PopFront()
+		require.False(t, ok, "PopFront on an empty deque should return false")
+		require.Equal(t, 0, actual, "PopFront on an empty deque should return zero value for int")
+	})
+
+	t.Run("PopFront from an non-empty deque should be true", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.PushBack(1)
+		deque.PushBack(2)
+		deque.PushFront(0)
+
+		for _, expected := range []int{0, 1, 2} {
+			actual, ok := deque.PopFront()
+			require.True(t, ok, "PopFront should return true for non-empty deque")
+			require.Equal(t, expected, actual, "PopFront should return the front value")
+		}
+
+		_, ok := deque.PopFront()
+		require.False(t, ok, "PopFront should return false for an empty deque")
+		require.True(t, deque.Empty(), "deque should be empty")
+	})
+}
+
+func testDeque_PopBack(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("PopBack from an empty deque should be false", func(t *testing.T) {
+		deque := newDequeFunc()
+		actual, ok := deque.PopBack()
+		require.False(t, ok, "PopBack on an empty deque should return false")
+		require.Equal(t, 0, actual, "PopBack on an empty deque should return zero value for int")
+	})
+
+	t.Run("PopBack from an non-empty deque should be true", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.PushFront(1)
+		deque.PushFront(0)
+		deque.PushBack(2)
+
+		for _, expected := range []int{2, 1, 0} {
+			actual, ok := deque.PopBack()
+			require.True(t, ok, "PopBack should return true for non-empty deque")
+			require.Equal(t, expected, actual, "PopBack should return the back value")
+		}
+
+		_, ok := deque.PopBack()
+		require.False(t, ok, "PopBack should return false for an empty deque")
+		require.True(t, deque.Empty(), "deque should be empty")
+	})
+}
+
+func testDeque_BothEnds(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	for i := 0; i < 5; i++ {
+		deque.PushBack(i)
+		deque.PushFront(-i - 1)
+	}
+	require.Equal(t, []int{-5, -4, -3, -2, -1, 0, 1, 2, 3, 4}, deque.ToSlice())
+
+	// alternate taking from both ends
+	actual := []int{}
+	for !deque.Empty() {
+		x, ok := deque.PopFront()
+		require.True(t, ok, "PopFront should return true for non-empty deque")
+		actual = append(actual, x)
+
+		x, ok = deque.PopBack()
+		require.True(t, ok, "PopBack should return true for non-empty deque")
+		actual = append(actual, x)
+	}
+	require.Equal(t, []int{-5, 4, -4, 3, -3, 2, -2, 1, -1, 0}, actual)
+	require.Equal(t, 0, deque.Size(), "deque size should be 0")
+
+	// the deque should still be usable after being drained
+	deque.PushFront(1)
+	deque.PushBack(2)
+	require.Equal(t, []int{1, 2}, deque.ToSlice())
+}
+
+func testDeque_All(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("All from an empty deque should yield nothing", func(t *testing.T) {
+		deque := newDequeFunc()
+		for range deque.All() {
+			require.Fail(t, "All should not yield for an empty deque")
+		}
+	})
+
+	t.Run("All should yield from the front to the back", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.PushBack(1)
+		deque.PushBack(2)
+		deque.PushFront(0)
+
+		actual := []int{}
+		for x := range deque.All() {
+			actual = append(actual, x)
+		}
+		require.Equal(t, []int{0, 1, 2}, actual)
+	})
+
+	t.Run("All should stop when the loop breaks", func(t *testing.T) {
+		deque := newDequeFunc()
+		for i := 0; i < 5; i++ {
+			deque.PushBack(i)
+		}
+
+		actual := []int{}
+		for x := range deque.All() {
+			if x == 2 {
+				break
+			}
+			actual = append(actual, x)
+		}
+		require.Equal(t, []int{0, 1}, actual)
+	})
+}
+
+func testDeque_Backward(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("Backward from an empty deque should yield nothing", func(t *testing.T) {
+		deque := newDequeFunc()
+		for range deque.Backward() {
+			require.Fail(t, "Backward should not yield for an empty deque")
+		}
+	})
+
+	t.Run("Backward should yield from the back to the front", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.PushBack(1)
+		deque.PushBack(2)
+		deque.PushFront(0)
+
+		actual := []int{}
+		for x := range deque.Backward() {
+			actual = append(actual, x)
+		}
+		require.Equal(t, []int{2, 1, 0}, actual)
+	})
+
+	t.Run("Backward should stop when the loop breaks", func(t *testing.T) {
+		deque := newDequeFunc()
+		for i := 0; i < 5; i++ {
+			deque.PushBack(i)
+		}
+
+		actual := []int{}
+		for x := range deque.Backward() {
+			if x == 2 {
+				break
+			}
+			actual = append(actual, x)
+		}
+		require.Equal(t, []int{4, 3}, actual)
+	})
+}
+
+func testDeque_Enumerate(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("Enumerate should yield the position from the front", func(t *testing.T) {
+		deque := newDequeFunc()
+		for i := 0; i < 3; i++ {
+			deque.PushBack(i * 10)
+		}
+		deque.PushFront(-10)
+
+		positions, values := []int{}, []int{}
+		for i, x := range deque.Enumerate() {
+			positions = append(positions, i)
+			values = append(values, x)
+		}
+		require.Equal(t, []int{0, 1, 2, 3}, positions)
+		require.Equal(t, []int{-10, 0, 10, 20}, values)
+	})
+
+	t.Run("Enumerate should stop when the loop breaks", func(t *testing.T) {
+		deque := newDequeFunc()
+		for i := 0; i < 5; i++ {
+			deque.PushBack(i)
+		}
+
+		count := 0
+		for i := range deque.Enumerate() {
+			if i == 2 {
+				break
+			}
+			count++
+		}
+		require.Equal(t, 2, count)
+	})
+}
+
+func isEven(x int) bool {
+	return x%2 == 0
+}
+
+func testDeque_ContainsFunc(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	require.False(t, deque.ContainsFunc(isEven), "an empty deque should not contain any element")
+
+	deque.Push(1)
+	deque.Push(3)
+	require.False(t, deque.ContainsFunc(isEven), "deque should not contain an even element")
+
+	deque.Push(4)
+	require.True(t, deque.ContainsFunc(isEven), "deque should contain an even element")
+}
+
+func testDeque_IndexFunc(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	require.Equal(t, -1, deque.IndexFunc(isEven), "an empty deque should not have any index")
+
+	for _, x := range []int{1, 3, 4, 6} {
+		deque.PushBack(x)
+	}
+	require.Equal(t, 2, deque.IndexFunc(isEven), "IndexFunc should return the first match from the front")
+
+	deque.PushFront(8)
+	require.Equal(t, 0, deque.IndexFunc(isEven), "IndexFunc should return the first match from the front")
+	require.Equal(t, -1, deque.IndexFunc(func(x int) bool { return x > 10 }))
+}
+
+func testDeque_RemoveFunc(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	require.False(t, deque.RemoveFunc(isEven), "RemoveFunc should return false for an empty deque")
+
+	for _, x := range []int{1, 2, 3, 4} {
+		deque.PushBack(x)
+	}
+	require.True(t, deque.RemoveFunc(isEven), "RemoveFunc should return true")
+	require.Equal(t, []int{1, 3, 4}, deque.ToSlice(), "RemoveFunc should remove the first match only")
+
+	require.True(t, deque.RemoveFunc(isEven), "RemoveFunc should return true")
+	require.Equal(t, []int{1, 3}, deque.ToSlice())
+
+	require.False(t, deque.RemoveFunc(isEven), "RemoveFunc should return false without a match")
+	require.Equal(t, 2, deque.Size(), "deque size should be 2")
+}
+
+// RunIncomparableDequeTests checks that a deque can store elements
+// which do not support the == operator
+func RunIncomparableDequeTests(
+	t *testing.T,
+	newDequeFunc func(equal func(a, b []int) bool) collection.Deque[[]int],
+) {
+	t.Run("Contains and Remove should use the equality function", func(t *testing.T) {
+		deque := newDequeFunc(slices.Equal[[]int])
+		deque.PushBack([]int{1, 2})
+		deque.PushBack([]int{3})
+		deque.PushFront(nil)
+
+		require.True(t, deque.Contains([]int{1, 2}), "deque should contain an equal slice")
+		require.False(t, deque.Contains([]int{2, 1}), "deque should not contain a different slice")
+
+		require.True(t, deque.Remove([]int{1, 2}), "Remove should return true for an equal slice")
+		require.False(t, deque.Remove([]int{1, 2}), "Remove should return false once removed")
+		require.Equal(t, [][]int{nil, {3}}, deque.ToSlice())
+	})
+
+	t.Run("Contains and Remove should panic without an equality function", func(t *testing.T) {
+		deque := newDequeFunc(nil)
+		deque.PushBack([]int{1})
+
+		require.Panics(t, func() { deque.Contains([]int{1}) }, "slices are not comparable")
+		require.Panics(t, func() { deque.Remove([]int{1}) }, "slices are not comparable")
+		require.Panics(t, func() { deque.RemoveAll([]int{1}) }, "slices are not comparable")
+		require.Equal(t, [][]int{{1}}, deque.ToSlice())
+	})
+
+	t.Run("predicates should work without an equality function", func(t *testing.T) {
+		deque := newDequeFunc(nil)
+		deque.PushBack([]int{1})
+		deque.PushBack([]int{1, 2})
+
+		isPair := func(x []int) bool { return len(x) == 2 }
+		require.True(t, deque.ContainsFunc(isPair))
+		require.Equal(t, 1, deque.IndexFunc(isPair))
+		require.True(t, deque.RemoveFunc(isPair))
+		require.Equal(t, [][]int{{1}}, deque.ToSlice())
+	})
+}
+
+func testDeque_At(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	_, err := deque.At(0)
+	require.ErrorIs(t, err, collection.ErrIndexOutOfRange, "At on an empty deque should fail")
+
+	deque.PushBack(1)
+	deque.PushBack(2)
+	deque.PushFront(0)
+	for i := 0; i < 3; i++ {
+		actual, err := deque.At(i)
+		require.NoError(t, err)
+		require.Equal(t, i, actual, "At should count the position from the front")
+	}
+
+	_, err = deque.At(-1)
+	require.ErrorIs(t, err, collection.ErrIndexOutOfRange, "At with a negative position should fail")
+	_, err = deque.At(3)
+	require.ErrorIs(t, err, collection.ErrIndexOutOfRange, "At with a position past the back should fail")
+}
+
+func testDeque_Set(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	require.ErrorIs(t, deque.Set(0, 1), collection.ErrIndexOutOfRange, "Set on an empty deque should fail")
+
+	for i := 0; i < 3; i++ {
+		deque.PushBack(i)
+	}
+	require.NoError(t, deque.Set(0, 10))
+	require.NoError(t, deque.Set(2, 12))
+	require.Equal(t, []int{10, 1, 12}, deque.ToSlice())
+
+	require.ErrorIs(t, deque.Set(-1, 0), collection.ErrIndexOutOfRange)
+	require.ErrorIs(t, deque.Set(3, 0), collection.ErrIndexOutOfRange)
+	require.Equal(t, 3, deque.Size(), "a failed Set should not change the size")
+}
+
+func testDeque_Insert(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("Insert into an empty deque", func(t *testing.T) {
+		deque := newDequeFunc()
+		require.ErrorIs(t, deque.Insert(1, 1), collection.ErrIndexOutOfRange)
+		require.NoError(t, deque.Insert(0, 1))
+		require.Equal(t, []int{1}, deque.ToSlice())
+	})
+
+	t.Run("Insert at the front, the middle and the back", func(t *testing.T) {
+		deque := newDequeFunc()
+		for _, x := range []int{1, 3, 5} {
+			deque.PushBack(x)
+		}
+		require.NoError(t, deque.Insert(0, 0))
+		require.NoError(t, deque.Insert(2, 2))
+		require.NoError(t, deque.Insert(4, 4))
+		require.NoError(t, deque.Insert(6, 6))
+		require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, deque.ToSlice())
+
+		front, _ := deque.Front()
+		require.Equal(t, 0, front)
+		back, _ := deque.Back()
+		require.Equal(t, 6, back)
+	})
+
+	t.Run("Insert out of range should fail", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.PushBack(1)
+		require.ErrorIs(t, deque.Insert(-1, 0), collection.ErrIndexOutOfRange)
+		require.ErrorIs(t, deque.Insert(2, 0), collection.ErrIndexOutOfRange)
+		require.Equal(t, []int{1}, deque.ToSlice())
+	})
+}
+
+func testDeque_RemoveAt(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("RemoveAt from an empty deque should fail", func(t *testing.T) {
+		deque := newDequeFunc()
+		_, err := deque.RemoveAt(0)
+		require.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+	})
+
+	t.Run("RemoveAt the front, the middle and the back", func(t *testing.T) {
+		deque := newDequeFunc()
+		for i := 0; i < 7; i++ {
+			deque.PushBack(i)
+		}
+
+		for _, testCase := range []struct{ position, expected int }{
+			{0, 0}, {2, 3}, {4, 6}, {1, 2},
+		} {
+			actual, err := deque.RemoveAt(testCase.position)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, actual)
+		}
+		require.Equal(t, []int{1, 4, 5}, deque.ToSlice())
+		require.Equal(t, 3, deque.Size(), "deque size should be 3 after removing 4 elements")
+
+		_, err := deque.RemoveAt(3)
+		require.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+		_, err = deque.RemoveAt(-1)
+		require.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+	})
+}
+
+func testDeque_IndexedModel(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	model := []int{}
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for i := 0; i < 1000; i++ {
+		switch op := random.IntN(4); {
+		case op == 0 || len(model) == 0:
+			position := random.IntN(len(model) + 1)
+			require.NoError(t, deque.Insert(position, i))
+			model = slices.Insert(model, position, i)
+		case op == 1:
+			position := random.IntN(len(model))
+			actual, err := deque.RemoveAt(position)
+			require.NoError(t, err)
+			require.Equal(t, model[position], actual)
+			model = slices.Delete(model, position, position+1)
+		case op == 2:
+			position := random.IntN(len(model))
+			require.NoError(t, deque.Set(position, -i))
+			model[position] = -i
+		default:
+			position := random.IntN(len(model))
+			actual, err := deque.At(position)
+			require.NoError(t, err)
+			require.Equal(t, model[position], actual)
+		}
+	}
+
+	require.Equal(t, len(model), deque.Size())
+	require.Equal(t, model, deque.ToSlice())
+}
+
+func testDeque_Rotate(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("Rotate an empty deque should do nothing", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.Rotate(3)
+		require.True(t, deque.Empty())
+	})
+
+	for _, testCase := range []struct {
+		steps    int
+		expected []int
+	}{
+		{0, []int{0, 1, 2, 3, 4}},
+		{1, []int{4, 0, 1, 2, 3}},
+		{4, []int{1, 2, 3, 4, 0}},
+		{7, []int{3, 4, 0, 1, 2}},
+		{-1, []int{1, 2, 3, 4, 0}},
+		{-6, []int{1, 2, 3, 4, 0}},
+		{5, []int{0, 1, 2, 3, 4}},
+	} {
+		deque := newDequeFunc()
+		for i := 0; i < 5; i++ {
+			deque.PushBack(i)
+		}
+		deque.Rotate(testCase.steps)
+		require.Equal(t, testCase.expected, deque.ToSlice(), "Rotate(%d)", testCase.steps)
+		require.Equal(t, testCase.expected, slices.Collect(deque.All()), "Rotate(%d)", testCase.steps)
+
+		front, _ := deque.Front()
+		require.Equal(t, testCase.expected[0], front)
+		back, _ := deque.Back()
+		require.Equal(t, testCase.expected[4], back)
+	}
+}
+
+func testDeque_Swap(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	require.ErrorIs(t, deque.Swap(0, 0), collection.ErrIndexOutOfRange, "Swap on an empty deque should fail")
+
+	for i := 0; i < 4; i++ {
+		deque.PushBack(i)
+	}
+	require.NoError(t, deque.Swap(0, 3))
+	require.NoError(t, deque.Swap(2, 1))
+	require.NoError(t, deque.Swap(1, 1))
+	require.Equal(t, []int{3, 2, 1, 0}, deque.ToSlice())
+
+	require.ErrorIs(t, deque.Swap(-1, 0), collection.ErrIndexOutOfRange)
+	require.ErrorIs(t, deque.Swap(0, 4), collection.ErrIndexOutOfRange)
+	require.Equal(t, []int{3, 2, 1, 0}, deque.ToSlice(), "a failed Swap should not change the deque")
+}
+
+func testDeque_Extend(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	deque.ExtendBack()
+	deque.ExtendFront()
+	require.True(t, deque.Empty(), "extending with nothing should do nothing")
+
+	deque.ExtendBack(3, 4, 5)
+	deque.ExtendFront(2, 1, 0)
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5}, deque.ToSlice(),
+		"ExtendFront should add the elements in reverse order")
+	require.Equal(t, 6, deque.Size())
+
+	back, _ := deque.Back()
+	require.Equal(t, 5, back)
+}
+
+func testDeque_DrainTo(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Run("DrainTo should move up to max elements from the front", func(t *testing.T) {
+		src, dst := newDequeFunc(), newDequeFunc()
+		src.ExtendBack(0, 1, 2, 3, 4)
+		dst.PushBack(-1)
+
+		require.Equal(t, 2, src.DrainTo(dst, 2))
+		require.Equal(t, []int{2, 3, 4}, src.ToSlice())
+		require.Equal(t, []int{-1, 0, 1}, dst.ToSlice())
+
+		require.Equal(t, 0, src.DrainTo(dst, 0))
+		require.Equal(t, 3, src.DrainTo(dst, 10), "DrainTo should stop once empty")
+		require.True(t, src.Empty())
+		require.Equal(t, []int{-1, 0, 1, 2, 3, 4}, dst.ToSlice())
+	})
+
+	t.Run("DrainTo with a negative max should move all the elements", func(t *testing.T) {
+		src, dst := newDequeFunc(), newDequeFunc()
+		src.ExtendBack(0, 1, 2)
+		require.Equal(t, 3, src.DrainTo(dst, -1))
+		require.True(t, src.Empty())
+		require.Equal(t, []int{0, 1, 2}, dst.ToSlice())
+	})
+
+	t.Run("DrainTo a bounded deque should stop at the first rejected element", func(t *testing.T) {
+		src, dst := newDequeFunc(), collection.NewBoundedRingDeque[int](2, collection.OverflowReject)
+		src.ExtendBack(0, 1, 2, 3, 4)
+		require.Equal(t, 2, src.DrainTo(dst, -1))
+		require.Equal(t, []int{2, 3, 4}, src.ToSlice())
+		require.Equal(t, []int{0, 1}, dst.ToSlice())
+	})
+
+	t.Run("DrainTo itself should terminate", func(t *testing.T) {
+		deque := newDequeFunc()
+		deque.ExtendBack(0, 1, 2)
+		require.Equal(t, 3, deque.DrainTo(deque, -1))
+		require.Equal(t, []int{0, 1, 2}, deque.ToSlice())
+	})
+}
+
+func testDeque_RemoveAll(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	deque := newDequeFunc()
+	require.Equal(t, 0, deque.RemoveAll(1), "RemoveAll on an empty deque should remove nothing")
+
+	deque.ExtendBack(1, 2, 1, 3, 1, 4, 1)
+	require.Equal(t, 4, deque.RemoveAll(1))
+	require.Equal(t, []int{2, 3, 4}, deque.ToSlice())
+	require.Equal(t, 0, deque.RemoveAll(1), "RemoveAll should remove nothing without a match")
+
+	require.Equal(t, 2, deque.RemoveAllFunc(isEven))
+	require.Equal(t, []int{3}, deque.ToSlice())
+	require.Equal(t, 1, deque.Size())
+
+	require.Equal(t, 1, deque.RemoveAllFunc(func(int) bool { return true }))
+	require.True(t, deque.Empty())
+	deque.PushBack(5)
+	require.Equal(t, []int{5}, deque.ToSlice(), "deque should be usable after removing everything")
+}
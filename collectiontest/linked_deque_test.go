@@ -10,4 +10,5 @@ func TestLinkedDeque(t *testing.T) {
 	RunDequeTests(t, func() collection.Deque[int] {
 		return collection.NewLinkedDeque[int]()
 	})
+	RunIncomparableDequeTests(t, collection.NewLinkedDequeFunc[[]int])
 }
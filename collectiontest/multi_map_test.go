@@ -0,0 +1,93 @@
+package collectiontest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestMultiMap(t *testing.T) {
+	t.Run("Put, Get and Count", func(t *testing.T) {
+		m := collection.NewMultiMap[string, int]()
+		require.True(t, m.Empty(), "a new map should be empty")
+		require.Nil(t, m.Get("a"), "Get should return nil for a missing key")
+
+		m.Put("b", 1)
+		m.Put("a", 2, 3)
+		m.Put("b", 4)
+		m.Put("c")
+		require.Equal(t, []int{1, 4}, m.Get("b"), "values should keep the order they were added")
+		require.Equal(t, []int{2, 3}, m.Get("a"))
+		require.Equal(t, 2, m.Count("a"))
+		require.Zero(t, m.Count("c"))
+		require.False(t, m.ContainsKey("c"), "Put without values should not add the key")
+		require.Equal(t, 4, m.Len())
+		require.Equal(t, 2, m.KeyLen())
+		require.Equal(t, []string{"b", "a"}, slices.Collect(m.Keys()), "keys should keep the order they were first added")
+	})
+
+	t.Run("Len should count the values kept by bounded deques", func(t *testing.T) {
+		for _, policy := range []collection.OverflowPolicy{
+			collection.OverflowReject,
+			collection.OverflowDropOldest,
+		} {
+			m := collection.NewMultiMapFunc[string](func() collection.Deque[int] {
+				return collection.NewBoundedRingDeque[int](2, policy)
+			})
+			m.Put("a", 1, 2, 3)
+			m.Put("a", 4)
+			m.Put("b", 5)
+			require.Equal(t, 2, m.Count("a"))
+			require.Equal(t, 3, m.Len())
+
+			m.Delete("a")
+			require.Equal(t, 1, m.Len())
+			require.True(t, m.Remove("b", 5))
+			require.True(t, m.Empty())
+		}
+	})
+
+	t.Run("Remove and Delete", func(t *testing.T) {
+		m := collection.NewMultiMap[string, int]()
+		m.Put("a", 1, 2, 1)
+
+		require.True(t, m.Contains("a", 1))
+		require.True(t, m.Remove("a", 1), "Remove should return true for an existing value")
+		require.Equal(t, []int{2, 1}, m.Get("a"), "Remove should remove the first occurrence")
+		require.False(t, m.Remove("a", 3))
+		require.False(t, m.Remove("b", 1))
+
+		m.Remove("a", 2)
+		m.Remove("a", 1)
+		require.False(t, m.ContainsKey("a"), "a key without values should be removed")
+		require.True(t, m.Empty())
+
+		m.Put("b", 5, 6)
+		require.Equal(t, []int{5, 6}, m.Delete("b"))
+		require.Nil(t, m.Delete("b"))
+		require.Zero(t, m.Len())
+	})
+
+	t.Run("All should yield every pair", func(t *testing.T) {
+		m := collection.NewMultiMapFunc[int](func() collection.Deque[[]int] {
+			return collection.NewLinkedDequeFunc[[]int](nil)
+		})
+		m.Put(1, []int{1}, []int{1, 1})
+		m.Put(2, []int{2})
+
+		keys, values := []int{}, [][]int{}
+		for key, value := range m.All() {
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		require.Equal(t, []int{1, 1, 2}, keys)
+		require.Equal(t, [][]int{{1}, {1, 1}, {2}}, values)
+
+		m.Clear()
+		require.True(t, m.Empty())
+		require.Empty(t, slices.Collect(m.Keys()))
+	})
+}
@@ -0,0 +1,200 @@
+package collectiontest
+
+import (
+	"encoding/json"
+	"maps"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestOrderedMap(t *testing.T) {
+	// newMap creates a map with the keys a, b, c whose values are 1, 2, 3
+	newMap := func() *collection.OrderedMap[string, int] {
+		m := collection.NewOrderedMap[string, int]()
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("c", 3)
+		return m
+	}
+	keys := func(m *collection.OrderedMap[string, int]) []string {
+		return slices.Collect(m.Keys())
+	}
+
+	t.Run("Get, Set and Delete", func(t *testing.T) {
+		m := collection.NewOrderedMap[string, int]()
+		_, ok := m.Get("a")
+		require.False(t, ok, "Get on an empty map should return false")
+
+		m.Set("a", 1)
+		m.Set("b", 2)
+		actual, ok := m.Get("a")
+		require.True(t, ok)
+		require.Equal(t, 1, actual)
+		require.True(t, m.Contains("b"))
+		require.Equal(t, 2, m.Len())
+
+		require.True(t, m.Delete("a"), "Delete should return true for an existing key")
+		require.False(t, m.Delete("a"), "Delete should return false once deleted")
+		require.False(t, m.Contains("a"))
+		require.Equal(t, []string{"b"}, keys(m))
+	})
+
+	t.Run("iterators should follow the insertion order", func(t *testing.T) {
+		m := newMap()
+		m.Set("a", 10)
+		require.Equal(t, []string{"a", "b", "c"}, keys(m), "updating a key should keep its position")
+		require.Equal(t, []int{10, 2, 3}, slices.Collect(m.Values()))
+		require.Equal(t, map[string]int{"a": 10, "b": 2, "c": 3}, maps.Collect(m.All()))
+
+		backward := []string{}
+		for key := range m.Backward() {
+			backward = append(backward, key)
+		}
+		require.Equal(t, []string{"c", "b", "a"}, backward)
+
+		m.Delete("a")
+		m.Set("a", 1)
+		require.Equal(t, []string{"b", "c", "a"}, keys(m), "a deleted key should be added to the back")
+	})
+
+	t.Run("zero value should be an empty map", func(t *testing.T) {
+		var m collection.OrderedMap[string, int]
+		require.Zero(t, m.Len())
+		require.False(t, m.Delete("a"))
+		m.Set("b", 2)
+		m.Set("a", 1)
+		require.Equal(t, []string{"b", "a"}, keys(&m))
+	})
+
+	t.Run("deleting visited keys while iterating should visit every key", func(t *testing.T) {
+		m := newMap()
+		visited := []string{}
+		for key := range m.All() {
+			if len(visited) > 0 {
+				m.Delete(visited[len(visited)-1])
+			}
+			visited = append(visited, key)
+		}
+		require.Equal(t, []string{"a", "b", "c"}, visited)
+		require.Equal(t, []string{"c"}, keys(m))
+	})
+
+	t.Run("deleting while iterating should continue with the next key", func(t *testing.T) {
+		m := newMap()
+		visited := []string{}
+		for key := range m.All() {
+			visited = append(visited, key)
+			m.Delete(key)
+		}
+		require.Equal(t, []string{"a", "b", "c"}, visited)
+		require.Equal(t, 0, m.Len())
+	})
+
+	t.Run("moving while iterating should visit every key once", func(t *testing.T) {
+		m := newMap()
+		visited := []string{}
+		for key := range m.All() {
+			visited = append(visited, key)
+			m.MoveToBack(key)
+		}
+		require.Equal(t, []string{"a", "b", "c"}, visited)
+		require.Equal(t, []string{"a", "b", "c"}, keys(m))
+
+		visited = []string{}
+		for key := range m.Backward() {
+			visited = append(visited, key)
+			m.MoveToFront(key)
+		}
+		require.Equal(t, []string{"c", "b", "a"}, visited)
+		require.Equal(t, []string{"a", "b", "c"}, keys(m))
+
+		visited = []string{}
+		for key := range m.All() {
+			visited = append(visited, key)
+			m.MoveToFront(key)
+		}
+		require.Equal(t, []string{"a", "b", "c"}, visited)
+		require.Equal(t, []string{"c", "b", "a"}, keys(m))
+	})
+
+	t.Run("MoveToFront and MoveToBack", func(t *testing.T) {
+		m := newMap()
+		require.True(t, m.MoveToFront("c"))
+		require.Equal(t, []string{"c", "a", "b"}, keys(m))
+		require.True(t, m.MoveToBack("c"))
+		require.True(t, m.MoveToBack("a"))
+		require.Equal(t, []string{"b", "c", "a"}, keys(m))
+		require.True(t, m.MoveToFront("b"), "moving the front key to the front should be fine")
+		require.Equal(t, []string{"b", "c", "a"}, keys(m))
+
+		require.False(t, m.MoveToFront("z"), "MoveToFront should return false for a missing key")
+		require.False(t, m.MoveToBack("z"), "MoveToBack should return false for a missing key")
+		require.Equal(t, 3, m.Len())
+	})
+
+	t.Run("Front, Back, PopFront and PopBack", func(t *testing.T) {
+		m := newMap()
+		key, value, ok := m.Front()
+		require.True(t, ok)
+		require.Equal(t, "a", key)
+		require.Equal(t, 1, value)
+		key, value, ok = m.Back()
+		require.True(t, ok)
+		require.Equal(t, "c", key)
+		require.Equal(t, 3, value)
+
+		key, _, _ = m.PopFront()
+		require.Equal(t, "a", key)
+		key, _, _ = m.PopBack()
+		require.Equal(t, "c", key)
+		require.False(t, m.Contains("a"))
+		require.False(t, m.Contains("c"))
+		require.Equal(t, []string{"b"}, keys(m))
+
+		m.Clear()
+		_, _, ok = m.PopFront()
+		require.False(t, ok, "PopFront on an empty map should return false")
+		_, _, ok = m.Back()
+		require.False(t, ok, "Back on an empty map should return false")
+	})
+
+	t.Run("MarshalJSON should keep the order", func(t *testing.T) {
+		m := collection.NewOrderedMap[string, any]()
+		m.Set("z", 1)
+		m.Set("a", []int{2})
+		m.Set("m", nil)
+		m.Set("\"quoted\"", "x")
+		actual, err := json.Marshal(m)
+		require.NoError(t, err)
+		require.Equal(t, `{"z":1,"a":[2],"m":null,"\"quoted\"":"x"}`, string(actual))
+
+		empty, err := json.Marshal(collection.NewOrderedMap[string, int]())
+		require.NoError(t, err)
+		require.Equal(t, `{}`, string(empty))
+	})
+
+	t.Run("MarshalJSON should encode keys like encoding/json", func(t *testing.T) {
+		numbers := collection.NewOrderedMap[int, bool]()
+		numbers.Set(10, true)
+		numbers.Set(-2, false)
+		actual, err := json.Marshal(numbers)
+		require.NoError(t, err)
+		require.Equal(t, `{"10":true,"-2":false}`, string(actual))
+
+		addresses := collection.NewOrderedMap[netip.Addr, int]()
+		addresses.Set(netip.MustParseAddr("10.0.0.1"), 1)
+		actual, err = json.Marshal(addresses)
+		require.NoError(t, err)
+		require.Equal(t, `{"10.0.0.1":1}`, string(actual))
+
+		floats := collection.NewOrderedMap[float64, int]()
+		floats.Set(1.5, 1)
+		_, err = json.Marshal(floats)
+		require.ErrorContains(t, err, "unsupported JSON key type float64")
+	})
+}
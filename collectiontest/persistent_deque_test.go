@@ -0,0 +1,142 @@
+package collectiontest
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestPersistentDeque(t *testing.T) {
+	t.Run("zero value should be an empty deque", func(t *testing.T) {
+		var deque collection.PersistentDeque[int]
+		require.True(t, deque.Empty())
+		_, ok := deque.Front()
+		require.False(t, ok, "Front should return false for empty deque")
+		_, _, ok = deque.PopBack()
+		require.False(t, ok, "PopBack should return false for empty deque")
+
+		deque = deque.PushBack(1).PushFront(0)
+		require.Equal(t, []int{0, 1}, deque.ToSlice())
+		require.True(t, deque.Contains(1), "zero value should compare with ==")
+	})
+
+	t.Run("a copy of a deque should compare the elements like the deque", func(t *testing.T) {
+		source := collection.NewBoundedDequeOf(collection.NewRingDequeFunc(slices.Equal[[]int]), 4, collection.OverflowReject)
+		source.ExtendBack([]int{1}, []int{1, 2})
+
+		deque := collection.NewPersistentDequeOf[[]int](source)
+		require.Equal(t, [][]int{{1}, {1, 2}}, deque.ToSlice())
+		require.True(t, deque.Contains([]int{1, 2}))
+		require.False(t, deque.Contains([]int{2}))
+	})
+
+	t.Run("every version should be unaffected by later versions", func(t *testing.T) {
+		versions := []collection.PersistentDeque[int]{collection.NewPersistentDeque[int]()}
+		for i := 1; i <= 20; i++ {
+			versions = append(versions, versions[i-1].PushBack(i))
+		}
+		_, popped, _ := versions[20].PopFront()
+		branch := versions[10].PushFront(-1)
+
+		for i, version := range versions {
+			expected := []int{}
+			for j := 1; j <= i; j++ {
+				expected = append(expected, j)
+			}
+			require.Equal(t, expected, version.ToSlice(), "version %d", i)
+		}
+		require.Equal(t, 19, popped.Size())
+		require.Equal(t, []int{-1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, branch.ToSlice())
+	})
+
+	t.Run("views should match the elements", func(t *testing.T) {
+		deque := collection.NewPersistentDeque(1, 2, 3, 4, 5)
+		front, _ := deque.Front()
+		back, _ := deque.Back()
+		top, _ := deque.Top()
+		require.Equal(t, 1, front)
+		require.Equal(t, 5, back)
+		require.Equal(t, 5, top)
+		require.True(t, deque.Contains(3))
+		require.False(t, deque.Contains(6))
+		require.Equal(t, 3, deque.IndexFunc(func(x int) bool { return x > 3 }))
+		require.Equal(t, []int{5, 4, 3, 2, 1}, slices.Collect(deque.Backward()))
+
+		for i := 0; i < deque.Size(); i++ {
+			actual, err := deque.At(i)
+			require.NoError(t, err)
+			require.Equal(t, i+1, actual)
+		}
+		_, err := deque.At(5)
+		require.ErrorIs(t, err, collection.ErrIndexOutOfRange)
+	})
+
+	t.Run("random operations should match a slice model", func(t *testing.T) {
+		deque := collection.NewPersistentDequeFunc[int](nil)
+		model := []int{}
+		random := rand.New(rand.NewPCG(1, 2))
+
+		for i := 0; i < 2000; i++ {
+			switch random.IntN(4) {
+			case 0:
+				deque = deque.PushFront(i)
+				model = slices.Insert(model, 0, i)
+			case 1:
+				deque = deque.PushBack(i)
+				model = append(model, i)
+			case 2:
+				x, next, ok := deque.PopFront()
+				require.Equal(t, len(model) > 0, ok)
+				if ok {
+					require.Equal(t, model[0], x)
+					model = model[1:]
+				}
+				deque = next
+			default:
+				x, next, ok := deque.PopBack()
+				require.Equal(t, len(model) > 0, ok)
+				if ok {
+					require.Equal(t, model[len(model)-1], x)
+					model = model[:len(model)-1]
+				}
+				deque = next
+			}
+
+			require.Equal(t, len(model), deque.Size())
+			if len(model) > 0 {
+				position := random.IntN(len(model))
+				actual, err := deque.At(position)
+				require.NoError(t, err)
+				require.Equal(t, model[position], actual)
+			}
+		}
+		require.Equal(t, model, deque.ToSlice())
+	})
+}
+
+func TestSnapshotDeque(t *testing.T) {
+	RunDequeTests(t, func() collection.Deque[int] {
+		return collection.NewSnapshotDeque[int]()
+	})
+	RunIncomparableDequeTests(t, func(equal func(a, b []int) bool) collection.Deque[[]int] {
+		return collection.NewSnapshotDequeFunc(equal)
+	})
+
+	t.Run("snapshot should not see later modifications", func(t *testing.T) {
+		deque := collection.NewSnapshotDeque[int]()
+		deque.ExtendBack(1, 2, 3)
+		snapshot := deque.Snapshot()
+
+		deque.PushFront(0)
+		deque.Reverse()
+		require.NoError(t, deque.Set(1, 10))
+		deque.Clear()
+
+		require.Equal(t, []int{1, 2, 3}, snapshot.ToSlice())
+		require.True(t, deque.Empty())
+	})
+}
@@ -0,0 +1,11 @@
+package collectiontest
+
+import (
+	"testing"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	RunPriorityQueueTests(t, collection.NewPriorityQueue[int])
+}
@@ -0,0 +1,238 @@
+package collectiontest
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func RunPriorityQueueTests(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	testCases := []struct {
+		name string
+		test func(t *testing.T, newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int])
+	}{
+		{"testPriorityQueue_Empty", testPriorityQueue_Empty},
+		{"testPriorityQueue_PushPop", testPriorityQueue_PushPop},
+		{"testPriorityQueue_Less", testPriorityQueue_Less},
+		{"testPriorityQueue_Update", testPriorityQueue_Update},
+		{"testPriorityQueue_Remove", testPriorityQueue_Remove},
+		{"testPriorityQueue_Clear", testPriorityQueue_Clear},
+		{"testPriorityQueue_Model", testPriorityQueue_Model},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.test(t, newQueueFunc)
+		})
+	}
+}
+
+func lessInt(a, b int) bool {
+	return a < b
+}
+
+func testPriorityQueue_Empty(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	queue := newQueueFunc(lessInt)
+	require.True(t, queue.Empty(), "a new queue should be empty")
+	require.Equal(t, 0, queue.Len())
+
+	actual, ok := queue.Pop()
+	require.False(t, ok, "Pop on an empty queue should return false")
+	require.Equal(t, 0, actual, "Pop on an empty queue should return zero value for int")
+
+	_, ok = queue.Peek()
+	require.False(t, ok, "Peek on an empty queue should return false")
+}
+
+func testPriorityQueue_PushPop(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	queue := newQueueFunc(lessInt)
+	for _, x := range []int{5, 1, 4, 1, 3, 9, 2} {
+		queue.Push(x)
+	}
+	require.Equal(t, 7, queue.Len())
+
+	peekVal, ok := queue.Peek()
+	require.True(t, ok, "Peek should return true for non-empty queue")
+	require.Equal(t, 1, peekVal, "Peek should return the least element")
+	require.Equal(t, 7, queue.Len(), "Peek should not remove the element")
+
+	actual := []int{}
+	for !queue.Empty() {
+		x, ok := queue.Pop()
+		require.True(t, ok, "Pop should return true for non-empty queue")
+		actual = append(actual, x)
+	}
+	require.Equal(t, []int{1, 1, 2, 3, 4, 5, 9}, actual, "Pop should return the least element first")
+}
+
+func testPriorityQueue_Less(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	queue := newQueueFunc(func(a, b int) bool { return a > b })
+	for _, x := range []int{2, 7, 1, 8} {
+		queue.Push(x)
+	}
+
+	actual := []int{}
+	for !queue.Empty() {
+		x, _ := queue.Pop()
+		actual = append(actual, x)
+	}
+	require.Equal(t, []int{8, 7, 2, 1}, actual, "the less function should decide the order")
+}
+
+func testPriorityQueue_Update(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	queue := newQueueFunc(lessInt)
+	handles := []*collection.Handle[int]{}
+	for _, x := range []int{10, 20, 30, 40} {
+		handles = append(handles, queue.Push(x))
+	}
+
+	require.True(t, queue.Update(handles[3], 5), "Update should return true for a queued element")
+	peekVal, _ := queue.Peek()
+	require.Equal(t, 5, peekVal, "a decreased element should move to the front")
+
+	require.True(t, queue.Update(handles[3], 50))
+	require.True(t, queue.Update(handles[0], 35))
+	actual := []int{}
+	for !queue.Empty() {
+		x, _ := queue.Pop()
+		actual = append(actual, x)
+	}
+	require.Equal(t, []int{20, 30, 35, 50}, actual)
+
+	require.False(t, queue.Update(handles[0], 1), "Update should return false for a popped element")
+	require.False(t, queue.Update(nil, 1), "Update should return false for a nil handle")
+	require.True(t, queue.Empty(), "a failed Update should not add the element")
+}
+
+func testPriorityQueue_Remove(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	queue := newQueueFunc(lessInt)
+	handles := []*collection.Handle[int]{}
+	for i := 0; i < 6; i++ {
+		handles = append(handles, queue.Push(i))
+	}
+
+	for _, i := range []int{0, 3, 5} {
+		actual, ok := queue.Remove(handles[i])
+		require.True(t, ok, "Remove should return true for a queued element")
+		require.Equal(t, i, actual)
+	}
+	require.Equal(t, 3, queue.Len())
+
+	_, ok := queue.Remove(handles[3])
+	require.False(t, ok, "Remove should return false once removed")
+
+	other := newQueueFunc(lessInt)
+	other.Push(100)
+	_, ok = queue.Remove(other.Push(101))
+	require.False(t, ok, "Remove should return false for the handle of another queue")
+	require.Equal(t, 2, other.Len())
+
+	actual := []int{}
+	for !queue.Empty() {
+		x, _ := queue.Pop()
+		actual = append(actual, x)
+	}
+	require.Equal(t, []int{1, 2, 4}, actual)
+}
+
+func testPriorityQueue_Clear(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	queue := newQueueFunc(lessInt)
+	handle := queue.Push(1)
+	queue.Push(2)
+
+	queue.Clear()
+	require.True(t, queue.Empty(), "queue should be empty after Clear")
+	_, ok := queue.Remove(handle)
+	require.False(t, ok, "Remove should return false after Clear")
+
+	queue.Push(3)
+	peekVal, _ := queue.Peek()
+	require.Equal(t, 3, peekVal, "queue should be usable after Clear")
+}
+
+func testPriorityQueue_Model(
+	t *testing.T,
+	newQueueFunc func(less func(a, b int) bool) collection.PriorityQueue[int],
+) {
+	queue := newQueueFunc(lessInt)
+	model := map[*collection.Handle[int]]int{}
+	random := rand.New(rand.NewPCG(1, 2))
+	// the elements are unique so that a popped element tells its handle,
+	// random priorities are in the high digits and i in the low ones
+	unique := func(i int) int {
+		return random.IntN(100)*10000 + i
+	}
+
+	// pick returns a random handle still in the queue
+	pick := func() *collection.Handle[int] {
+		handles := make([]*collection.Handle[int], 0, len(model))
+		for handle := range model {
+			handles = append(handles, handle)
+		}
+		// sorting keeps the test deterministic despite the map order
+		slices.SortFunc(handles, func(a, b *collection.Handle[int]) int {
+			return cmp.Compare(model[a], model[b])
+		})
+		return handles[random.IntN(len(handles))]
+	}
+	least := func() (*collection.Handle[int], int) {
+		var leastHandle *collection.Handle[int]
+		for handle, x := range model {
+			if leastHandle == nil || x < model[leastHandle] {
+				leastHandle = handle
+			}
+		}
+		return leastHandle, model[leastHandle]
+	}
+
+	for i := 0; i < 1000; i++ {
+		switch op := random.IntN(4); {
+		case op == 0 || len(model) == 0:
+			x := unique(i)
+			model[queue.Push(x)] = x
+		case op == 1:
+			handle, expected := least()
+			actual, ok := queue.Pop()
+			require.True(t, ok)
+			require.Equal(t, expected, actual)
+			delete(model, handle)
+		case op == 2:
+			handle, x := pick(), unique(i)
+			require.True(t, queue.Update(handle, x))
+			model[handle] = x
+		default:
+			handle := pick()
+			actual, ok := queue.Remove(handle)
+			require.True(t, ok)
+			require.Equal(t, model[handle], actual)
+			delete(model, handle)
+		}
+		require.Equal(t, len(model), queue.Len())
+	}
+}
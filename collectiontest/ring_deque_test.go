@@ -0,0 +1,42 @@
+package collectiontest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestRingDeque(t *testing.T) {
+	RunDequeTests(t, func() collection.Deque[int] {
+		return collection.NewRingDeque[int]()
+	})
+	RunIncomparableDequeTests(t, collection.NewRingDequeFunc[[]int])
+
+	t.Run("long running FIFO should wrap around the buffer", func(t *testing.T) {
+		deque := collection.NewRingDeque[int]()
+		next, expected := 0, 0
+		for round := 0; round < 100; round++ {
+			// grow past a few buffer sizes then shrink most of it back
+			for i := 0; i < 50; i++ {
+				deque.Enqueue(next)
+				next++
+			}
+			for i := 0; i < 45; i++ {
+				actual, ok := deque.Dequeue()
+				require.True(t, ok, "Dequeue should return true for non-empty deque")
+				require.Equal(t, expected, actual, "Dequeue should preserve FIFO order")
+				expected++
+			}
+		}
+
+		require.Equal(t, next-expected, deque.Size())
+		front, ok := deque.Front()
+		require.True(t, ok, "Front should return true for non-empty deque")
+		require.Equal(t, expected, front)
+		back, ok := deque.Back()
+		require.True(t, ok, "Back should return true for non-empty deque")
+		require.Equal(t, next-1, back)
+	})
+}
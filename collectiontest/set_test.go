@@ -0,0 +1,21 @@
+package collectiontest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestSet(t *testing.T) {
+	RunSetTests(t, func() collection.Set[int] {
+		return collection.NewSet[int]()
+	})
+
+	t.Run("NewSet should hold the unique elements", func(t *testing.T) {
+		set := collection.NewSet(1, 2, 2, 3, 1)
+		require.Equal(t, 3, set.Len())
+		require.Equal(t, []int{1, 2, 3}, sorted(set))
+	})
+}
@@ -0,0 +1,137 @@
+package collectiontest
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func RunSetTests(t *testing.T, newSetFunc func() collection.Set[int]) {
+	testCases := []struct {
+		name string
+		test func(t *testing.T, newSetFunc func() collection.Set[int])
+	}{
+		{"testSet_AddRemove", testSet_AddRemove},
+		{"testSet_Clear", testSet_Clear},
+		{"testSet_Union", testSet_Union},
+		{"testSet_Intersection", testSet_Intersection},
+		{"testSet_Difference", testSet_Difference},
+		{"testSet_SymmetricDifference", testSet_SymmetricDifference},
+		{"testSet_IsSubset", testSet_IsSubset},
+		{"testSet_All", testSet_All},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.test(t, newSetFunc)
+		})
+	}
+}
+
+// setOf creates a set with the constructor under test
+func setOf(newSetFunc func() collection.Set[int], xs ...int) collection.Set[int] {
+	set := newSetFunc()
+	for _, x := range xs {
+		set.Add(x)
+	}
+	return set
+}
+
+// sorted returns the elements of the set in ascending order
+func sorted(set collection.Set[int]) []int {
+	slice := set.ToSlice()
+	slices.Sort(slice)
+	return slice
+}
+
+func testSet_AddRemove(t *testing.T, newSetFunc func() collection.Set[int]) {
+	set := newSetFunc()
+	require.True(t, set.Empty(), "a new set should be empty")
+	require.Equal(t, 0, set.Len())
+
+	require.True(t, set.Add(1), "Add should return true for a new element")
+	require.True(t, set.Add(2))
+	require.False(t, set.Add(1), "Add should return false for an existing element")
+	require.Equal(t, 2, set.Len(), "set size should not count duplicates")
+
+	require.True(t, set.Contains(1))
+	require.False(t, set.Contains(3))
+
+	require.True(t, set.Remove(1), "Remove should return true for an existing element")
+	require.False(t, set.Remove(1), "Remove should return false once removed")
+	require.False(t, set.Contains(1))
+	require.Equal(t, []int{2}, set.ToSlice())
+}
+
+func testSet_Clear(t *testing.T, newSetFunc func() collection.Set[int]) {
+	set := setOf(newSetFunc, 1, 2, 3)
+	set.Clear()
+	require.True(t, set.Empty(), "set should be empty after Clear")
+	require.False(t, set.Contains(1))
+
+	set.Add(4)
+	require.Equal(t, []int{4}, set.ToSlice(), "set should be usable after Clear")
+}
+
+func testSet_Union(t *testing.T, newSetFunc func() collection.Set[int]) {
+	a, b := setOf(newSetFunc, 1, 2, 3), setOf(newSetFunc, 3, 4)
+	require.Equal(t, []int{1, 2, 3, 4}, sorted(a.Union(b)))
+	require.Equal(t, []int{1, 2, 3, 4}, sorted(b.Union(a)))
+	require.Equal(t, []int{1, 2, 3}, sorted(a.Union(newSetFunc())))
+	require.Equal(t, []int{1, 2, 3}, sorted(a.Union(collection.NewSet(1))),
+		"Union should accept another kind of set")
+
+	require.Equal(t, []int{1, 2, 3}, sorted(a), "Union should not change the sets")
+	require.Equal(t, []int{3, 4}, sorted(b), "Union should not change the sets")
+}
+
+func testSet_Intersection(t *testing.T, newSetFunc func() collection.Set[int]) {
+	a, b := setOf(newSetFunc, 1, 2, 3), setOf(newSetFunc, 2, 3, 4)
+	require.Equal(t, []int{2, 3}, sorted(a.Intersection(b)))
+	require.Equal(t, []int{2, 3}, sorted(b.Intersection(a)))
+	require.True(t, a.Intersection(newSetFunc()).Empty())
+
+	require.Equal(t, []int{1, 2, 3}, sorted(a), "Intersection should not change the sets")
+}
+
+func testSet_Difference(t *testing.T, newSetFunc func() collection.Set[int]) {
+	a, b := setOf(newSetFunc, 1, 2, 3), setOf(newSetFunc, 2, 3, 4)
+	require.Equal(t, []int{1}, sorted(a.Difference(b)))
+	require.Equal(t, []int{4}, sorted(b.Difference(a)))
+	require.Equal(t, []int{1, 2, 3}, sorted(a.Difference(newSetFunc())))
+	require.True(t, a.Difference(a).Empty())
+}
+
+func testSet_SymmetricDifference(t *testing.T, newSetFunc func() collection.Set[int]) {
+	a, b := setOf(newSetFunc, 1, 2, 3), setOf(newSetFunc, 2, 3, 4)
+	require.Equal(t, []int{1, 4}, sorted(a.SymmetricDifference(b)))
+	require.Equal(t, []int{1, 4}, sorted(b.SymmetricDifference(a)))
+	require.True(t, a.SymmetricDifference(a).Empty())
+
+	require.Equal(t, []int{1, 2, 3}, sorted(a), "SymmetricDifference should not change the sets")
+}
+
+func testSet_IsSubset(t *testing.T, newSetFunc func() collection.Set[int]) {
+	a, b := setOf(newSetFunc, 1, 2), setOf(newSetFunc, 1, 2, 3)
+	require.True(t, a.IsSubset(b))
+	require.False(t, b.IsSubset(a))
+	require.True(t, a.IsSubset(a), "a set should be a subset of itself")
+	require.True(t, newSetFunc().IsSubset(a), "an empty set should be a subset of any set")
+	require.False(t, setOf(newSetFunc, 1, 4).IsSubset(b))
+}
+
+func testSet_All(t *testing.T, newSetFunc func() collection.Set[int]) {
+	set := setOf(newSetFunc, 3, 1, 2)
+	actual := slices.Sorted(set.All())
+	require.Equal(t, []int{1, 2, 3}, actual)
+
+	count := 0
+	for range set.All() {
+		count++
+		break
+	}
+	require.Equal(t, 1, count, "All should stop when the loop breaks")
+}
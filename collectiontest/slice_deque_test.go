@@ -10,4 +10,5 @@ func TestSliceDeque(t *testing.T) {
 	RunDequeTests(t, func() collection.Deque[int] {
 		return collection.NewSliceDeque[int]()
 	})
+	RunIncomparableDequeTests(t, collection.NewSliceDequeFunc[[]int])
 }
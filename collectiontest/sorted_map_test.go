@@ -0,0 +1,32 @@
+package collectiontest
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func TestSortedMap(t *testing.T) {
+	RunSortedMapTests(t, collection.NewSortedMap[int, string])
+
+	t.Run("the comparator should decide the order", func(t *testing.T) {
+		m := collection.NewSortedMapFunc[string, int](func(a, b string) int {
+			return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+		m.Set("b", 1)
+		m.Set("A", 2)
+		m.Set("C", 3)
+		m.Set("a", 4)
+
+		require.Equal(t, []string{"A", "b", "C"}, keysOf(m.All()),
+			"keys equal for the comparator should be the same key")
+		value, _ := m.Get("A")
+		require.Equal(t, 4, value)
+		floor, _, _ := m.Floor("Bz")
+		require.Equal(t, "b", floor)
+	})
+}
@@ -0,0 +1,225 @@
+package collectiontest
+
+import (
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+func RunSortedMapTests(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	testCases := []struct {
+		name string
+		test func(t *testing.T, newMapFunc func() collection.SortedMap[int, string])
+	}{
+		{"testSortedMap_GetSetDelete", testSortedMap_GetSetDelete},
+		{"testSortedMap_Empty", testSortedMap_Empty},
+		{"testSortedMap_MinMax", testSortedMap_MinMax},
+		{"testSortedMap_FloorCeiling", testSortedMap_FloorCeiling},
+		{"testSortedMap_Range", testSortedMap_Range},
+		{"testSortedMap_Iterators", testSortedMap_Iterators},
+		{"testSortedMap_Model", testSortedMap_Model},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.test(t, newMapFunc)
+		})
+	}
+}
+
+// sortedMapOf creates a map whose values are the keys prefixed with "v"
+func sortedMapOf(newMapFunc func() collection.SortedMap[int, string], keys ...int) collection.SortedMap[int, string] {
+	m := newMapFunc()
+	for _, key := range keys {
+		m.Set(key, valueOf(key))
+	}
+	return m
+}
+
+// keysOf collects the keys of the iterator
+func keysOf[K any, V any](seq iter.Seq2[K, V]) []K {
+	keys := []K{}
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func valueOf(key int) string {
+	return "v" + strconv.Itoa(key)
+}
+
+func testSortedMap_GetSetDelete(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	m := newMapFunc()
+	_, ok := m.Get(1)
+	require.False(t, ok, "Get on an empty map should return false")
+
+	m.Set(2, "b")
+	m.Set(1, "a")
+	m.Set(2, "B")
+	actual, ok := m.Get(2)
+	require.True(t, ok)
+	require.Equal(t, "B", actual, "Set should replace the value")
+	require.True(t, m.Contains(1))
+	require.False(t, m.Contains(3))
+	require.Equal(t, 2, m.Len())
+
+	require.True(t, m.Delete(1), "Delete should return true for an existing key")
+	require.False(t, m.Delete(1), "Delete should return false once deleted")
+	require.False(t, m.Contains(1))
+	require.Equal(t, 1, m.Len())
+}
+
+func testSortedMap_Empty(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	m := newMapFunc()
+	require.True(t, m.Empty(), "a new map should be empty")
+
+	for _, view := range []func() (int, string, bool){m.Min, m.Max, m.PopMin, m.PopMax} {
+		key, value, ok := view()
+		require.False(t, ok, "views of an empty map should return false")
+		require.Zero(t, key)
+		require.Zero(t, value)
+	}
+	_, _, ok := m.Floor(1)
+	require.False(t, ok)
+	_, _, ok = m.Ceiling(1)
+	require.False(t, ok)
+
+	m = sortedMapOf(newMapFunc, 1, 2, 3)
+	m.Clear()
+	require.True(t, m.Empty(), "map should be empty after Clear")
+	_, _, ok = m.Min()
+	require.False(t, ok)
+	m.Set(4, "d")
+	require.Equal(t, []int{4}, keysOf(m.All()), "map should be usable after Clear")
+}
+
+func testSortedMap_MinMax(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	m := sortedMapOf(newMapFunc, 5, 3, 8, 1, 9)
+	key, value, ok := m.Min()
+	require.True(t, ok)
+	require.Equal(t, 1, key)
+	require.Equal(t, valueOf(1), value)
+	key, _, _ = m.Max()
+	require.Equal(t, 9, key)
+
+	popped := []int{}
+	for !m.Empty() {
+		key, _, ok := m.PopMin()
+		require.True(t, ok)
+		popped = append(popped, key)
+		if m.Empty() {
+			break
+		}
+		key, _, ok = m.PopMax()
+		require.True(t, ok)
+		popped = append(popped, key)
+	}
+	require.Equal(t, []int{1, 9, 3, 8, 5}, popped)
+}
+
+func testSortedMap_FloorCeiling(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	m := sortedMapOf(newMapFunc, 10, 20, 30)
+	for _, testCase := range []struct {
+		key                  int
+		floor, ceiling       int
+		hasFloor, hasCeiling bool
+	}{
+		{5, 0, 10, false, true},
+		{10, 10, 10, true, true},
+		{15, 10, 20, true, true},
+		{30, 30, 30, true, true},
+		{35, 30, 0, true, false},
+	} {
+		floor, _, ok := m.Floor(testCase.key)
+		require.Equal(t, testCase.hasFloor, ok, "Floor(%d)", testCase.key)
+		require.Equal(t, testCase.floor, floor, "Floor(%d)", testCase.key)
+
+		ceiling, _, ok := m.Ceiling(testCase.key)
+		require.Equal(t, testCase.hasCeiling, ok, "Ceiling(%d)", testCase.key)
+		require.Equal(t, testCase.ceiling, ceiling, "Ceiling(%d)", testCase.key)
+	}
+}
+
+func testSortedMap_Range(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	m := sortedMapOf(newMapFunc, 1, 3, 5, 7, 9)
+	for _, testCase := range []struct {
+		from, to int
+		expected []int
+	}{
+		{3, 7, []int{3, 5}},
+		{2, 8, []int{3, 5, 7}},
+		{0, 100, []int{1, 3, 5, 7, 9}},
+		{5, 5, []int{}},
+		{7, 3, []int{}},
+		{10, 20, []int{}},
+	} {
+		actual := keysOf(m.Range(testCase.from, testCase.to))
+		require.Equal(t, testCase.expected, actual, "Range(%d, %d)", testCase.from, testCase.to)
+	}
+
+	for key, value := range m.Range(0, 100) {
+		require.Equal(t, valueOf(key), value, "Range should yield the values")
+		break
+	}
+}
+
+func testSortedMap_Iterators(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	m := sortedMapOf(newMapFunc, 4, 2, 6, 0)
+	require.Equal(t, []int{0, 2, 4, 6}, keysOf(m.All()))
+	require.Equal(t, []int{6, 4, 2, 0}, keysOf(m.Backward()))
+
+	// deleting while iterating should continue with the next key
+	visited := []int{}
+	for key := range m.All() {
+		visited = append(visited, key)
+		m.Delete(key)
+	}
+	require.Equal(t, []int{0, 2, 4, 6}, visited)
+	require.True(t, m.Empty())
+}
+
+func testSortedMap_Model(t *testing.T, newMapFunc func() collection.SortedMap[int, string]) {
+	m := newMapFunc()
+	model := map[int]string{}
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for i := 0; i < 2000; i++ {
+		key := random.IntN(200)
+		switch random.IntN(4) {
+		case 0, 1:
+			m.Set(key, valueOf(i))
+			model[key] = valueOf(i)
+		case 2:
+			_, expected := model[key]
+			require.Equal(t, expected, m.Delete(key))
+			delete(model, key)
+		default:
+			floor, _, ok := m.Floor(key)
+			expectedFloor, expectedOk := -1, false
+			for k := range model {
+				if k <= key && k > expectedFloor {
+					expectedFloor, expectedOk = k, true
+				}
+			}
+			require.Equal(t, expectedOk, ok)
+			if ok {
+				require.Equal(t, expectedFloor, floor)
+			}
+		}
+	}
+
+	require.Equal(t, len(model), m.Len())
+	require.Equal(t, model, maps.Collect(m.All()))
+	require.Equal(t, slices.Sorted(maps.Keys(model)), keysOf(m.All()))
+	descending := slices.Sorted(maps.Keys(model))
+	slices.Reverse(descending)
+	require.Equal(t, descending, keysOf(m.Backward()))
+}
@@ -0,0 +1,165 @@
+package sync
+
+import (
+	"time"
+)
+
+// defaultAutoscaleInterval is the interval between two scaling decisions
+// when Autoscaling.Interval is not set
+const defaultAutoscaleInterval = 100 * time.Millisecond
+
+// Autoscaling configures a pool which adjusts its number of workers to
+// its load, within its minimum and maximum number of workers.
+//
+// The pool grows by one worker per pending task when more tasks than
+// QueueLength are pending, or when the oldest pending task has waited
+// longer than Wait. A zero threshold is ignored.
+// The pool stops its idle workers once it has had idle workers and no
+// pending task for the idle timeout set with WithIdleTimeout, it never
+// shrinks without an idle timeout.
+type Autoscaling struct {
+	// Interval is the interval between two scaling decisions,
+	// defaults to 100ms
+	Interval    time.Duration
+	QueueLength int
+	Wait        time.Duration
+}
+
+// ScaleReason is the reason of a scaling decision
+type ScaleReason string
+
+const (
+	// ScaleQueueLength grows the pool since too many tasks are pending
+	ScaleQueueLength ScaleReason = "queue length"
+	// ScaleWait grows the pool since a task has waited for too long
+	ScaleWait ScaleReason = "wait time"
+	// ScaleIdle shrinks the pool since some workers are idle
+	ScaleIdle ScaleReason = "idle"
+)
+
+// ScaleEvent describes a scaling decision of an autoscaling pool
+type ScaleEvent struct {
+	// Pool is the name of the pool
+	Pool   string
+	Reason ScaleReason
+	From   int
+	To     int
+	// QueueLength and Wait are the number of pending tasks and the wait
+	// of the oldest one when the decision was made
+	QueueLength int
+	Wait        time.Duration
+	// Idle is the number of workers not running a task
+	Idle int
+}
+
+// WithAutoscaling makes the pool adjust its number of workers to its load
+// Pools have a fixed number of workers by default.
+func WithAutoscaling(autoscaling Autoscaling) WorkerPoolOption {
+	return withAutoscaling(autoscaling)
+}
+
+type withAutoscaling Autoscaling
+
+func (w withAutoscaling) Apply(settings *workerPoolSettings) {
+	autoscaling := Autoscaling(w)
+	if autoscaling.Interval <= 0 {
+		autoscaling.Interval = defaultAutoscaleInterval
+	}
+	settings.autoscaling = &autoscaling
+}
+
+// WithOnScale calls the callback after each scaling decision of an
+// autoscaling pool, e.g. to report the size of the pool
+func WithOnScale(callback func(ScaleEvent)) WorkerPoolOption {
+	return withOnScale(callback)
+}
+
+type withOnScale func(ScaleEvent)
+
+func (w withOnScale) Apply(settings *workerPoolSettings) {
+	settings.onScale = w
+}
+
+// autoscale makes a scaling decision at every interval
+// until the pool is closed
+func (p *WorkerPool) autoscale() {
+	ticker := time.NewTicker(p.autoscaling.Interval)
+	defer ticker.Stop()
+
+	// idleSince is when the pool started to have idle workers
+	var idleSince time.Time
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-p.closing:
+			return
+		case now := <-ticker.C:
+			event, ok := p.scale(now, &idleSince)
+			if ok && p.onScale != nil {
+				p.onScale(event)
+			}
+		}
+	}
+}
+
+// scale grows or shrinks the pool according to its load
+// false if the number of workers is unchanged
+func (p *WorkerPool) scale(now time.Time, idleSince *time.Time) (ScaleEvent, bool) {
+	queueLength, wait := p.load(now)
+
+	// the submissions waiting for space do not hold the read lock,
+	// so the pool can grow while its task queue is full
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.isClosed {
+		return ScaleEvent{}, false
+	}
+
+	event := ScaleEvent{
+		Pool:        p.name,
+		From:        p.numWorkers,
+		QueueLength: queueLength,
+		Wait:        wait,
+		Idle:        max(p.numWorkers-int(p.busy.Load()), 0),
+	}
+	switch {
+	case p.autoscaling.QueueLength > 0 && queueLength > p.autoscaling.QueueLength:
+		event.Reason = ScaleQueueLength
+		event.To = min(p.numWorkers+queueLength, p.maxWorkers)
+	case p.autoscaling.Wait > 0 && wait > p.autoscaling.Wait:
+		event.Reason = ScaleWait
+		event.To = min(p.numWorkers+queueLength, p.maxWorkers)
+	case queueLength > 0 || event.Idle == 0 || p.idleTimeout <= 0:
+		*idleSince = time.Time{}
+		return ScaleEvent{}, false
+	case idleSince.IsZero():
+		*idleSince = now
+		return ScaleEvent{}, false
+	case now.Sub(*idleSince) < p.idleTimeout:
+		return ScaleEvent{}, false
+	default:
+		event.Reason = ScaleIdle
+		event.To = max(p.numWorkers-event.Idle, p.minWorkers)
+	}
+
+	*idleSince = time.Time{}
+	if event.To == event.From {
+		return ScaleEvent{}, false
+	}
+	// the idle workers take from doneChan right away
+	p.resize(event.To)
+	return event, true
+}
+
+// load returns the number of pending tasks and the wait of the oldest one
+func (p *WorkerPool) load(now time.Time) (int, time.Duration) {
+	p.queueLock.Lock()
+	defer p.queueLock.Unlock()
+
+	_, submitted, ok := p.pending.Front()
+	if !ok {
+		return p.queue.Len(), 0
+	}
+	return p.queue.Len(), now.Sub(submitted)
+}
@@ -0,0 +1,175 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerPool_Autoscaling(t *testing.T) {
+	// recorder collects the scaling decisions
+	type recorder struct {
+		lock   sync.Mutex
+		events []ScaleEvent
+	}
+	record := func(r *recorder) WorkerPoolOption {
+		return WithOnScale(func(event ScaleEvent) {
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			r.events = append(r.events, event)
+		})
+	}
+	first := func(r *recorder) ScaleEvent {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		require.NotEmpty(t, r.events)
+		return r.events[0]
+	}
+
+	t.Run("pool should grow with the queue length up to the maximum", func(t *testing.T) {
+		t.Parallel()
+		events := &recorder{}
+		pool := NewWorkerPool(context.Background(), 1, 10,
+			WithName("queue"),
+			WithMaxWorkers(3),
+			WithAutoscaling(Autoscaling{Interval: 5 * time.Millisecond, QueueLength: 1}),
+			record(events),
+		)
+		release := make(chan struct{})
+		defer pool.Close()
+		defer close(release)
+
+		for i := 0; i < 6; i++ {
+			require.NoError(t, pool.Submit(func() { <-release }))
+		}
+		require.Eventually(t, func() bool {
+			return pool.Workers() == 3
+		}, time.Second, time.Millisecond)
+
+		event := first(events)
+		require.Equal(t, "queue", event.Pool)
+		require.Equal(t, ScaleQueueLength, event.Reason)
+		require.Equal(t, 1, event.From)
+		require.Equal(t, 3, event.To, "the pool should not grow beyond the maximum")
+		require.Greater(t, event.QueueLength, 1)
+
+		time.Sleep(20 * time.Millisecond)
+		require.Equal(t, 3, pool.Workers())
+	})
+
+	t.Run("pool should grow when a task waits too long", func(t *testing.T) {
+		t.Parallel()
+		events := &recorder{}
+		pool := NewWorkerPool(context.Background(), 1, 10,
+			WithMaxWorkers(4),
+			WithAutoscaling(Autoscaling{Interval: 5 * time.Millisecond, Wait: 20 * time.Millisecond}),
+			record(events),
+		)
+		release := make(chan struct{})
+		defer pool.Close()
+		defer close(release)
+
+		require.NoError(t, pool.Submit(func() { <-release }))
+		done := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { close(done) }))
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			require.Fail(t, "the waiting task should run on a new worker")
+		}
+		event := first(events)
+		require.Equal(t, ScaleWait, event.Reason)
+		require.Equal(t, 1, event.From)
+		require.Equal(t, 2, event.To)
+		require.Greater(t, event.Wait, 20*time.Millisecond)
+	})
+
+	t.Run("pool should grow while submissions wait for space", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 2,
+			WithMaxWorkers(4),
+			WithAutoscaling(Autoscaling{Interval: 5 * time.Millisecond, Wait: 20 * time.Millisecond}),
+		)
+		release := make(chan struct{})
+		defer pool.Close()
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, pool.SubmitWait(context.Background(), func() { <-release }))
+		}
+		errs := make(chan error, 4)
+		for i := 0; i < 4; i++ {
+			go func() {
+				errs <- pool.SubmitWait(context.Background(), func() { <-release })
+			}()
+		}
+
+		require.Eventually(t, func() bool {
+			return pool.Workers() == 4
+		}, time.Second, time.Millisecond, "the pool should grow while the queue is full")
+
+		close(release)
+		for i := 0; i < 4; i++ {
+			select {
+			case err := <-errs:
+				require.NoError(t, err)
+			case <-time.After(time.Second):
+				require.Fail(t, "SubmitWait did not return in time")
+			}
+		}
+	})
+
+	t.Run("pool should shrink idle workers down to the minimum", func(t *testing.T) {
+		t.Parallel()
+		events := &recorder{}
+		pool := NewWorkerPool(context.Background(), 4, 10,
+			WithMinWorkers(2),
+			WithIdleTimeout(20*time.Millisecond),
+			WithAutoscaling(Autoscaling{Interval: 5 * time.Millisecond, QueueLength: 5}),
+			record(events),
+		)
+		release := make(chan struct{})
+		defer pool.Close()
+
+		// a busy worker should not be stopped
+		require.NoError(t, pool.Submit(func() { <-release }))
+		require.Eventually(t, func() bool {
+			return pool.Workers() == 2
+		}, time.Second, time.Millisecond)
+
+		event := first(events)
+		require.Equal(t, ScaleIdle, event.Reason)
+		require.Equal(t, 4, event.From)
+		require.Equal(t, 2, event.To)
+		require.Equal(t, 3, event.Idle)
+
+		close(release)
+		done := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { close(done) }))
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			require.Fail(t, "task did not execute in time")
+		}
+	})
+
+	t.Run("pool should stop scaling once closed", func(t *testing.T) {
+		t.Parallel()
+		events := &recorder{}
+		pool := NewWorkerPool(context.Background(), 2, 10,
+			WithIdleTimeout(10*time.Millisecond),
+			WithAutoscaling(Autoscaling{Interval: time.Millisecond}),
+			record(events),
+		)
+		pool.Close()
+		time.Sleep(50 * time.Millisecond)
+
+		events.lock.Lock()
+		defer events.lock.Unlock()
+		require.Empty(t, events.events)
+		require.Equal(t, 2, pool.Workers())
+	})
+}
@@ -0,0 +1,128 @@
+package sync
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// AtomicBitSet is a fixed size bit set whose bits are set and cleared
+// with atomic operations, so that it can be used as lock-free flags
+// shared by multiple goroutines.
+//
+// Each operation on a single bit is linearizable. Count, NextSet and the
+// iterator read the words one at a time and may observe concurrent
+// modifications partially.
+type AtomicBitSet struct {
+	words []atomic.Uint64
+	size  int
+}
+
+// NewAtomicBitSet creates an atomic bit set holding the bits below size
+func NewAtomicBitSet(size int) *AtomicBitSet {
+	return &AtomicBitSet{
+		words: make([]atomic.Uint64, (size+63)/64),
+		size:  size,
+	}
+}
+
+// bitIndex returns the word holding the bit and the mask of the bit
+// It panics if the bit is outside of the set.
+func (b *AtomicBitSet) bitIndex(i int) (*atomic.Uint64, uint64) {
+	if i < 0 || i >= b.size {
+		panic(fmt.Sprintf("sync: bit %d out of range with size %d", i, b.size))
+	}
+	return &b.words[i/64], 1 << (i % 64)
+}
+
+// Size returns the number of bits in the set
+func (b *AtomicBitSet) Size() int {
+	return b.size
+}
+
+// Set sets the bit and returns true if it was clear
+func (b *AtomicBitSet) Set(i int) bool {
+	word, mask := b.bitIndex(i)
+	return word.Or(mask)&mask == 0
+}
+
+// Clear clears the bit and returns true if it was set
+func (b *AtomicBitSet) Clear(i int) bool {
+	word, mask := b.bitIndex(i)
+	return word.And(^mask)&mask != 0
+}
+
+// Test checks if the bit is set
+func (b *AtomicBitSet) Test(i int) bool {
+	word, mask := b.bitIndex(i)
+	return word.Load()&mask != 0
+}
+
+// Flip flips the bit and returns true if it is now set
+func (b *AtomicBitSet) Flip(i int) bool {
+	word, mask := b.bitIndex(i)
+	for {
+		old := word.Load()
+		if word.CompareAndSwap(old, old^mask) {
+			return old&mask == 0
+		}
+	}
+}
+
+// Count returns the number of bits set
+func (b *AtomicBitSet) Count() int {
+	count := 0
+	for i := range b.words {
+		count += bits.OnesCount64(b.words[i].Load())
+	}
+	return count
+}
+
+// NextSet returns the first bit set from the bit included
+// -1 and a false if there is none
+func (b *AtomicBitSet) NextSet(i int) (int, bool) {
+	if i < 0 {
+		panic(fmt.Sprintf("sync: negative bit %d", i))
+	}
+	for word := i / 64; word < len(b.words); word++ {
+		w := b.words[word].Load()
+		if word == i/64 {
+			// skip the bits below i in the first word
+			w &= ^uint64(0) << (i % 64)
+		}
+		if w != 0 {
+			return word*64 + bits.TrailingZeros64(w), true
+		}
+	}
+	return -1, false
+}
+
+// ClearAll clears all the bits
+func (b *AtomicBitSet) ClearAll() {
+	for i := range b.words {
+		b.words[i].Store(0)
+	}
+}
+
+// All returns an iterator over the bits set in ascending order
+func (b *AtomicBitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot copies the bits into a collection.BitSet
+func (b *AtomicBitSet) Snapshot() *collection.BitSet {
+	snapshot := collection.NewBitSet(b.size)
+	for i := range b.All() {
+		snapshot.Set(i)
+	}
+	return snapshot
+}
@@ -0,0 +1,60 @@
+package sync
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicBitSet(t *testing.T) {
+	t.Run("Set, Clear and Flip should report the previous state", func(t *testing.T) {
+		b := NewAtomicBitSet(130)
+		require.Equal(t, 130, b.Size())
+
+		require.True(t, b.Set(129), "Set should return true for a clear bit")
+		require.False(t, b.Set(129), "Set should return false for a set bit")
+		require.True(t, b.Test(129))
+
+		require.True(t, b.Flip(64), "Flip should return true once set")
+		require.False(t, b.Flip(64), "Flip should return false once cleared")
+
+		b.Set(0)
+		require.Equal(t, 2, b.Count())
+		require.Equal(t, []int{0, 129}, slices.Collect(b.All()))
+		next, ok := b.NextSet(1)
+		require.True(t, ok)
+		require.Equal(t, 129, next)
+
+		require.True(t, b.Clear(0), "Clear should return true for a set bit")
+		require.False(t, b.Clear(0), "Clear should return false for a clear bit")
+		require.Equal(t, []int{129}, slices.Collect(b.Snapshot().All()))
+
+		b.ClearAll()
+		require.Zero(t, b.Count())
+		require.Panics(t, func() { b.Set(130) }, "bits beyond the size should panic")
+	})
+
+	t.Run("only one goroutine should win each bit", func(t *testing.T) {
+		b := NewAtomicBitSet(1000)
+		wins := atomic.Int64{}
+		wg := &sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < b.Size(); j++ {
+					if b.Set(j) {
+						wins.Add(1)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, int64(1000), wins.Load())
+		require.Equal(t, 1000, b.Count())
+	})
+}
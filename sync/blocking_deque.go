@@ -0,0 +1,205 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// ErrDequeClosed is returned when putting into a closed BlockingDeque,
+// or taking from a closed BlockingDeque which has no element left.
+var ErrDequeClosed = errors.New("deque has been closed")
+
+// BlockingDeque is a ConcurrentDeque which waits for an element
+// when taking from an empty deque, and for space when putting into
+// a full bounded deque.
+// Closing the deque wakes up all the waiters. The elements added before
+// closing can still be taken until the deque becomes empty.
+type BlockingDeque[X any] struct {
+	deque *ConcurrentDeque[X]
+
+	// lock is held to add or remove an element and to wait, so that
+	// each change wakes up a single waiter which can make progress
+	lock     sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	// closed is only set with the lock held
+	closed atomic.Bool
+}
+
+// NewBlockingDeque creates an unbounded BlockingDeque
+func NewBlockingDeque[X comparable]() *BlockingDeque[X] {
+	return newBlockingDeque(&ConcurrentDeque[X]{
+		deque: collection.NewRingDeque[X](),
+	})
+}
+
+// NewBlockingDequeFunc creates an unbounded BlockingDeque
+// which compares the elements with the equality function
+func NewBlockingDequeFunc[X any](equal func(a, b X) bool) *BlockingDeque[X] {
+	return newBlockingDeque(&ConcurrentDeque[X]{
+		deque: collection.NewRingDequeFunc(equal),
+	})
+}
+
+// NewBoundedBlockingDeque creates a BlockingDeque holding at most
+// capacity elements, Put and PutFront wait while the deque is full.
+func NewBoundedBlockingDeque[X comparable](capacity int) *BlockingDeque[X] {
+	return newBlockingDeque(
+		newBoundedConcurrentDeque(collection.NewRingDeque[X](), capacity, collection.OverflowReject),
+	)
+}
+
+// NewBoundedBlockingDequeFunc creates a BlockingDeque holding at most
+// capacity elements which compares the elements with the equality function
+func NewBoundedBlockingDequeFunc[X any](equal func(a, b X) bool, capacity int) *BlockingDeque[X] {
+	return newBlockingDeque(
+		newBoundedConcurrentDeque(collection.NewRingDequeFunc(equal), capacity, collection.OverflowReject),
+	)
+}
+
+func newBlockingDeque[X any](deque *ConcurrentDeque[X]) *BlockingDeque[X] {
+	q := &BlockingDeque[X]{deque: deque}
+	q.notEmpty = sync.NewCond(&q.lock)
+	q.notFull = sync.NewCond(&q.lock)
+	return q
+}
+
+// Put adds an element to the back of the deque,
+// waiting for space if the deque is full.
+// ErrDequeClosed if the deque is closed, or the context error
+// if the context is done before the element is added.
+func (q *BlockingDeque[X]) Put(ctx context.Context, x X) error {
+	return q.put(ctx, x, q.deque.OfferBack)
+}
+
+// PutFront adds an element to the front of the deque,
+// waiting for space if the deque is full.
+// ErrDequeClosed if the deque is closed, or the context error
+// if the context is done before the element is added.
+func (q *BlockingDeque[X]) PutFront(ctx context.Context, x X) error {
+	return q.put(ctx, x, q.deque.OfferFront)
+}
+
+func (q *BlockingDeque[X]) put(ctx context.Context, x X, offer func(X) bool) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	defer q.wakeOnDone(ctx, q.notFull)()
+
+	for {
+		if q.closed.Load() {
+			return ErrDequeClosed
+		}
+		if offer(x) {
+			q.notEmpty.Signal()
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			// pass on a wake up which was meant for this producer
+			q.notFull.Signal()
+			return err
+		}
+		q.notFull.Wait()
+	}
+}
+
+// Take removes an element from the front of the deque,
+// waiting for an element if the deque is empty.
+// ErrDequeClosed if the deque is closed and empty, or the context error
+// if the context is done before an element is available.
+func (q *BlockingDeque[X]) Take(ctx context.Context) (X, error) {
+	return q.take(ctx, q.deque.PopFront)
+}
+
+// TakeBack removes an element from the back of the deque,
+// waiting for an element if the deque is empty.
+// ErrDequeClosed if the deque is closed and empty, or the context error
+// if the context is done before an element is available.
+func (q *BlockingDeque[X]) TakeBack(ctx context.Context) (X, error) {
+	return q.take(ctx, q.deque.PopBack)
+}
+
+func (q *BlockingDeque[X]) take(ctx context.Context, pop func() (X, bool)) (X, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	defer q.wakeOnDone(ctx, q.notEmpty)()
+
+	for {
+		if x, ok := pop(); ok {
+			if q.deque.Capacity() > 0 {
+				q.notFull.Signal()
+			}
+			return x, nil
+		}
+
+		var zero X
+		if q.closed.Load() {
+			return zero, ErrDequeClosed
+		}
+		if err := ctx.Err(); err != nil {
+			// pass on a wake up which was meant for this consumer
+			q.notEmpty.Signal()
+			return zero, err
+		}
+		q.notEmpty.Wait()
+	}
+}
+
+// wakeOnDone wakes up the waiters of the condition once the context is
+// done, so that the waiter of the context can return.
+// The returned function stops waiting for the context.
+func (q *BlockingDeque[X]) wakeOnDone(ctx context.Context, cond *sync.Cond) func() bool {
+	return context.AfterFunc(ctx, func() {
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		cond.Broadcast()
+	})
+}
+
+// PollTimeout removes an element from the front of the deque,
+// waiting up to the timeout for an element if the deque is empty.
+// false if no element is available before the timeout
+// or if the deque is closed and empty.
+func (q *BlockingDeque[X]) PollTimeout(timeout time.Duration) (X, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	x, err := q.Take(ctx)
+	return x, err == nil
+}
+
+// Close closes the deque and wakes up all the waiters.
+// Elements can no longer be added, the remaining elements
+// can still be taken.
+func (q *BlockingDeque[X]) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.closed.Store(true)
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+// IsClosed returns whether this deque is closed
+func (q *BlockingDeque[X]) IsClosed() bool {
+	return q.closed.Load()
+}
+
+// Size returns the total number of elements in the deque
+func (q *BlockingDeque[X]) Size() int {
+	return q.deque.Size()
+}
+
+// Empty returns true if the deque has zero element
+func (q *BlockingDeque[X]) Empty() bool {
+	return q.deque.Empty()
+}
+
+// Capacity returns the maximum number of elements in the deque,
+// zero if the deque is unbounded
+func (q *BlockingDeque[X]) Capacity() int {
+	return q.deque.Capacity()
+}
@@ -0,0 +1,238 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockingDeque_Take(t *testing.T) {
+	t.Run("take should wait for an element", func(t *testing.T) {
+		t.Parallel()
+		q := NewBlockingDeque[int]()
+
+		put := make(chan error, 1)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			put <- q.Put(context.Background(), 42)
+		}()
+
+		x, err := q.Take(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 42, x)
+		require.NoError(t, <-put)
+	})
+
+	t.Run("take should follow the ordering of both ends", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		q := NewBlockingDeque[int]()
+		require.NoError(t, q.Put(ctx, 1))
+		require.NoError(t, q.Put(ctx, 2))
+		require.NoError(t, q.PutFront(ctx, 0))
+
+		x, err := q.Take(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 0, x)
+
+		x, err = q.TakeBack(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, x)
+		require.Equal(t, 1, q.Size())
+	})
+
+	t.Run("take should return when the context is done", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		q := NewBlockingDeque[int]()
+
+		_, err := q.Take(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestBlockingDeque_Put(t *testing.T) {
+	t.Run("put should wait for space in a bounded deque", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		q := NewBoundedBlockingDeque[int](1)
+		require.NoError(t, q.Put(ctx, 1))
+
+		taken := make(chan int, 1)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			x, _ := q.Take(ctx)
+			taken <- x
+		}()
+
+		require.NoError(t, q.Put(ctx, 2))
+		require.Equal(t, 1, <-taken)
+		x, err := q.Take(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, x)
+	})
+
+	t.Run("put should return when the context is done", func(t *testing.T) {
+		t.Parallel()
+		q := NewBoundedBlockingDeque[int](1)
+		require.NoError(t, q.Put(context.Background(), 1))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, q.PutFront(ctx, 2), context.DeadlineExceeded)
+		require.Equal(t, 1, q.Size())
+	})
+}
+
+func TestBlockingDeque_PollTimeout(t *testing.T) {
+	t.Parallel()
+	q := NewBlockingDeque[int]()
+
+	_, ok := q.PollTimeout(10 * time.Millisecond)
+	require.False(t, ok, "PollTimeout should return false on timeout")
+
+	require.NoError(t, q.Put(context.Background(), 1))
+	x, ok := q.PollTimeout(10 * time.Millisecond)
+	require.True(t, ok, "PollTimeout should return true for non-empty deque")
+	require.Equal(t, 1, x)
+}
+
+func TestBlockingDeque_Close(t *testing.T) {
+	t.Run("close should wake up all the waiters", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		q := NewBoundedBlockingDeque[int](1)
+		empty := NewBlockingDeque[int]()
+		require.NoError(t, q.Put(ctx, 1))
+
+		waiters := 5
+		errs := make(chan error, 2*waiters)
+		for i := 0; i < waiters; i++ {
+			go func() {
+				errs <- q.Put(ctx, 2)
+			}()
+			go func() {
+				_, err := empty.Take(ctx)
+				errs <- err
+			}()
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		q.Close()
+		empty.Close()
+
+		for i := 0; i < 2*waiters; i++ {
+			select {
+			case err := <-errs:
+				require.ErrorIs(t, err, ErrDequeClosed)
+			case <-time.After(time.Second):
+				require.Fail(t, "waiters were not woken up by Close")
+			}
+		}
+	})
+
+	t.Run("remaining elements should be taken after close", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		q := NewBlockingDeque[int]()
+		require.NoError(t, q.Put(ctx, 1))
+		require.NoError(t, q.Put(ctx, 2))
+		q.Close()
+		q.Close()
+		require.True(t, q.IsClosed())
+
+		require.ErrorIs(t, q.Put(ctx, 3), ErrDequeClosed)
+
+		x, err := q.Take(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, x)
+		x, err = q.TakeBack(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, x)
+
+		_, err = q.Take(ctx)
+		require.ErrorIs(t, err, ErrDequeClosed)
+	})
+}
+
+func TestBlockingDequeFunc(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	q := NewBoundedBlockingDequeFunc(slices.Equal[[]int], 2)
+
+	require.NoError(t, q.Put(ctx, []int{1}))
+	require.NoError(t, q.PutFront(ctx, []int{0}))
+	require.Equal(t, 2, q.Capacity())
+
+	x, err := q.Take(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, x)
+	x, err = q.TakeBack(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, x)
+}
+
+func TestBlockingDeque_ProducersConsumers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	q := NewBoundedBlockingDeque[int](4)
+
+	producers, consumers, perProducer := 4, 4, 100
+	// errs collects the errors of the goroutines to check them on the test goroutine
+	errs := make(chan error, producers*perProducer+consumers)
+	producerWg := &sync.WaitGroup{}
+	for i := 0; i < producers; i++ {
+		producerWg.Add(1)
+		go func(id int) {
+			defer producerWg.Done()
+			for j := 0; j < perProducer; j++ {
+				if err := q.Put(ctx, id*perProducer+j); err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+
+	lock := sync.Mutex{}
+	taken := []int{}
+	consumerWg := &sync.WaitGroup{}
+	for i := 0; i < consumers; i++ {
+		consumerWg.Add(1)
+		go func() {
+			defer consumerWg.Done()
+			for {
+				x, err := q.Take(ctx)
+				if err != nil {
+					if !errors.Is(err, ErrDequeClosed) {
+						errs <- err
+					}
+					return
+				}
+				lock.Lock()
+				taken = append(taken, x)
+				lock.Unlock()
+			}
+		}()
+	}
+
+	producerWg.Wait()
+	q.Close()
+	consumerWg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	sort.Ints(taken)
+	require.Len(t, taken, producers*perProducer)
+	for i, x := range taken {
+		require.Equal(t, i, x)
+	}
+}
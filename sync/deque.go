@@ -1,224 +1,542 @@
 package sync
 
 import (
+	"iter"
 	"sync"
+	"unsafe"
 
 	"github.com/kevin-ip/go-handy/collection"
 )
 
-// ConcurrentDeque is a queue backed by two internal deques
-// so that inserting and removing an element
-// can be performed concurrently.
-type ConcurrentDeque[X comparable] struct {
-	inLock  sync.RWMutex
-	outLock sync.RWMutex
-
-	inDeque  collection.Deque[X]
-	outDeque collection.Deque[X]
+// ConcurrentDeque is a deque guarded by a read-write lock
+// so that it can be shared by multiple goroutines.
+//
+// Every operation is linearizable: it takes effect atomically at some
+// point between its call and its return. The deque therefore keeps the
+// same semantics as collection.Deque, i.e. the top of the stack is the
+// back of the queue:
+//   - Push, Enqueue and PushBack add to the back
+//   - PushFront adds to the front
+//   - Pop and PopBack remove from the back, Dequeue and PopFront
+//     remove from the front
+//   - Peek, Top and Back view the back, Front views the front
+//   - ToSlice and the iterators list the elements from the front
+//     to the back
+//
+// Use LockFreeQueue when only FIFO operations are needed and
+// the producers and consumers should not contend on a lock.
+type ConcurrentDeque[X any] struct {
+	lock  sync.RWMutex
+	deque collection.Deque[X]
+
+	// capacity limits the total number of elements, zero if unbounded
+	capacity int
+	policy   collection.OverflowPolicy
 }
 
 // ConcurrentDeque backed by slice deque
 func NewConcurrentSliceDeque[X comparable]() collection.Deque[X] {
 	return &ConcurrentDeque[X]{
-		inDeque:  collection.NewSliceDeque[X](),
-		outDeque: collection.NewSliceDeque[X](),
+		deque: collection.NewSliceDeque[X](),
 	}
 }
 
 // ConcurrentDeque backed by linked deque
 func NewConcurrentLinkedDeque[X comparable]() collection.Deque[X] {
 	return &ConcurrentDeque[X]{
-		inDeque:  collection.NewLinkedDeque[X](),
-		outDeque: collection.NewLinkedDeque[X](),
+		deque: collection.NewLinkedDeque[X](),
 	}
 }
 
-func (q *ConcurrentDeque[X]) Push(x X) {
-	q.inLock.Lock()
-	defer q.inLock.Unlock()
+// ConcurrentDeque backed by ring deque
+func NewConcurrentRingDeque[X comparable]() collection.Deque[X] {
+	return &ConcurrentDeque[X]{
+		deque: collection.NewRingDeque[X](),
+	}
+}
 
-	q.inDeque.Push(x)
+// NewConcurrentSnapshotDeque creates a ConcurrentDeque backed by
+// a persistent deque, so that Snapshot, ToSlice and the iterators only
+// hold the lock for constant time
+func NewConcurrentSnapshotDeque[X comparable]() collection.Deque[X] {
+	return &ConcurrentDeque[X]{
+		deque: collection.NewSnapshotDeque[X](),
+	}
 }
 
-func (q *ConcurrentDeque[X]) Pop() (X, bool) {
-	value, ok := q.pop(q.inDeque, &q.inLock)
-	if ok {
-		return value, true
+// NewConcurrentDequeOf creates a ConcurrentDeque guarding the deque.
+// The deque should no longer be used directly.
+func NewConcurrentDequeOf[X any](deque collection.Deque[X]) collection.Deque[X] {
+	return &ConcurrentDeque[X]{
+		deque: deque,
 	}
-	return q.pop(q.outDeque, &q.outLock)
 }
 
-func (q *ConcurrentDeque[X]) pop(deque collection.Deque[X], lock *sync.RWMutex) (X, bool) {
-	lock.Lock()
-	defer lock.Unlock()
+// NewBoundedConcurrentSliceDeque creates a bounded ConcurrentDeque backed by slice deque
+func NewBoundedConcurrentSliceDeque[X comparable](
+	capacity int,
+	policy collection.OverflowPolicy,
+) collection.BoundedDeque[X] {
+	return newBoundedConcurrentDeque(collection.NewSliceDeque[X](), capacity, policy)
+}
 
-	return deque.Pop()
+// NewBoundedConcurrentLinkedDeque creates a bounded ConcurrentDeque backed by linked deque
+func NewBoundedConcurrentLinkedDeque[X comparable](
+	capacity int,
+	policy collection.OverflowPolicy,
+) collection.BoundedDeque[X] {
+	return newBoundedConcurrentDeque(collection.NewLinkedDeque[X](), capacity, policy)
 }
 
-func (q *ConcurrentDeque[X]) Peek() (X, bool) {
-	value, ok := q.peek(q.inDeque, &q.inLock)
-	if ok {
-		return value, true
+// NewBoundedConcurrentRingDeque creates a bounded ConcurrentDeque backed by ring deque
+func NewBoundedConcurrentRingDeque[X comparable](
+	capacity int,
+	policy collection.OverflowPolicy,
+) collection.BoundedDeque[X] {
+	return newBoundedConcurrentDeque(collection.NewRingDeque[X](), capacity, policy)
+}
+
+// NewBoundedConcurrentDequeOf creates a bounded ConcurrentDeque guarding the deque.
+// The deque should no longer be used directly.
+func NewBoundedConcurrentDequeOf[X any](
+	deque collection.Deque[X],
+	capacity int,
+	policy collection.OverflowPolicy,
+) collection.BoundedDeque[X] {
+	return newBoundedConcurrentDeque(deque, capacity, policy)
+}
+
+func newBoundedConcurrentDeque[X any](
+	deque collection.Deque[X],
+	capacity int,
+	policy collection.OverflowPolicy,
+) *ConcurrentDeque[X] {
+	if capacity < 1 {
+		panic("sync: bounded deque capacity must be positive")
 	}
-	return q.peek(q.outDeque, &q.outLock)
+	return &ConcurrentDeque[X]{
+		deque:    deque,
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// Push adds an element to the top of the deque, i.e. the back
+func (q *ConcurrentDeque[X]) Push(x X) {
+	q.OfferBack(x)
 }
 
-func (q *ConcurrentDeque[X]) peek(deque collection.Deque[X], lock *sync.RWMutex) (X, bool) {
-	lock.RLock()
-	defer lock.RUnlock()
+// Pop removes the top element from the deque, i.e. the back
+func (q *ConcurrentDeque[X]) Pop() (X, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.Pop()
+}
 
-	return deque.Peek()
+// Peek views the top element from the deque, i.e. the back
+func (q *ConcurrentDeque[X]) Peek() (X, bool) {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.Peek()
 }
 
+// Top views the top element from the deque
+// This is an alias for Peek but provides semantic clarity for stack
+// use cases.
 func (q *ConcurrentDeque[X]) Top() (X, bool) {
 	return q.Peek()
 }
 
 // Enqueue adds an element to the back of the queue
 func (q *ConcurrentDeque[X]) Enqueue(x X) {
-	q.inLock.Lock()
-	defer q.inLock.Unlock()
-	q.inDeque.Enqueue(x)
+	q.OfferBack(x)
 }
 
 // Dequeue removes an element from the front of the queue
 func (q *ConcurrentDeque[X]) Dequeue() (X, bool) {
-	// having lock acquired in methods to improve readability over performance
-	x, ok := q.dequeueFromOutDeque()
-	if ok {
-		return x, true
-	}
-	q.fillOutDeque()
-	return q.dequeueFromOutDeque()
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.Dequeue()
 }
 
-func (q *ConcurrentDeque[X]) dequeueFromOutDeque() (X, bool) {
-	q.outLock.Lock()
-	defer q.outLock.Unlock()
-	return q.outDeque.Dequeue()
+// Front views the first element of the queue
+// a zero value and a false if the queue is empty
+func (q *ConcurrentDeque[X]) Front() (X, bool) {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.Front()
+}
+
+// Back views the last element of the queue
+// a zero value and a false if the queue is empty
+func (q *ConcurrentDeque[X]) Back() (X, bool) {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.Back()
+}
+
+// PushFront adds an element to the front of the queue
+func (q *ConcurrentDeque[X]) PushFront(x X) {
+	q.OfferFront(x)
+}
+
+// PushBack adds an element to the back of the queue
+func (q *ConcurrentDeque[X]) PushBack(x X) {
+	q.OfferBack(x)
 }
 
-func (q *ConcurrentDeque[X]) fillOutDeque() {
-	q.inLock.Lock()
-	elements := q.inDeque.ToSlice()
-	q.inDeque.Clear()
-	q.inLock.Unlock()
+// PopFront removes the front element from the queue
+func (q *ConcurrentDeque[X]) PopFront() (X, bool) {
+	return q.Dequeue()
+}
+
+// PopBack removes the back element from the queue
+func (q *ConcurrentDeque[X]) PopBack() (X, bool) {
+	return q.Pop()
+}
 
-	q.outLock.Lock()
-	defer q.outLock.Unlock()
-	for _, value := range elements {
-		q.outDeque.Enqueue(value)
+// OfferFront adds an element to the front of the queue
+// false if the element is rejected because the queue is full
+func (q *ConcurrentDeque[X]) OfferFront(x X) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.offerFront(x)
+}
+
+// offerFront must be called with the lock held
+func (q *ConcurrentDeque[X]) offerFront(x X) bool {
+	if q.full() {
+		switch q.policy {
+		case collection.OverflowDropOldest:
+			q.deque.PopBack()
+		case collection.OverflowDropNewest:
+			q.deque.PopFront()
+		default:
+			return false
+		}
 	}
+	q.deque.PushFront(x)
+	return true
 }
 
-// Front views the first element of the queue
-// a zero value and a false if the queue is empty
-func (q *ConcurrentDeque[X]) Front() (X, bool) {
-	x, ok := q.front(q.outDeque, &q.outLock)
-	if ok {
-		return x, true
+// OfferBack adds an element to the back of the queue
+// false if the element is rejected because the queue is full
+func (q *ConcurrentDeque[X]) OfferBack(x X) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.offerBack(x)
+}
+
+// offerBack must be called with the lock held
+func (q *ConcurrentDeque[X]) offerBack(x X) bool {
+	if q.full() {
+		switch q.policy {
+		case collection.OverflowDropOldest:
+			q.deque.PopFront()
+		case collection.OverflowDropNewest:
+			q.deque.PopBack()
+		default:
+			return false
+		}
 	}
-	return q.front(q.inDeque, &q.inLock)
+	q.deque.PushBack(x)
+	return true
 }
 
-func (q *ConcurrentDeque[X]) front(deque collection.Deque[X], lock *sync.RWMutex) (X, bool) {
-	lock.RLock()
-	defer lock.RUnlock()
-	return deque.Front()
+// At views the element at the position from the front
+func (q *ConcurrentDeque[X]) At(i int) (X, error) {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.At(i)
 }
 
-// Back views the last element of the queue
-// a zero value and a false if the queue is empty
-func (q *ConcurrentDeque[X]) Back() (X, bool) {
-	// check the inDeque first as new element enqueues there
-	x, ok := q.back(q.inDeque, &q.inLock)
-	if ok {
-		return x, true
+// Set replaces the element at the position from the front
+func (q *ConcurrentDeque[X]) Set(i int, x X) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.Set(i, x)
+}
+
+// Insert adds an element at the position from the front
+// A bounded queue applies its overflow policy like OfferBack when full,
+// and returns collection.ErrDequeFull if the element is rejected.
+func (q *ConcurrentDeque[X]) Insert(i int, x X) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	// validate the position before evicting any element
+	size := q.deque.Size()
+	if i < 0 || i > size {
+		return q.deque.Insert(i, x)
 	}
-	return q.back(q.outDeque, &q.outLock)
+
+	if q.full() {
+		switch q.policy {
+		case collection.OverflowDropOldest:
+			q.deque.PopFront()
+			// the elements shift towards the front
+			i = max(i-1, 0)
+		case collection.OverflowDropNewest:
+			q.deque.PopBack()
+			i = min(i, size-1)
+		default:
+			return collection.ErrDequeFull
+		}
+	}
+	return q.deque.Insert(i, x)
+}
+
+// RemoveAt removes the element at the position from the front
+func (q *ConcurrentDeque[X]) RemoveAt(i int) (X, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.RemoveAt(i)
+}
+
+// Capacity returns the maximum number of elements in the queue,
+// zero if the queue is unbounded
+func (q *ConcurrentDeque[X]) Capacity() int {
+	return q.capacity
 }
 
-func (q *ConcurrentDeque[X]) back(deque collection.Deque[X], lock *sync.RWMutex) (X, bool) {
-	lock.RLock()
-	defer lock.RUnlock()
-	return deque.Back()
+// Full returns true if the queue has reached its capacity,
+// an unbounded queue is never full
+func (q *ConcurrentDeque[X]) Full() bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.full()
+}
+
+// full must be called with the lock held
+func (q *ConcurrentDeque[X]) full() bool {
+	return q.capacity > 0 && q.deque.Size() >= q.capacity
 }
 
 // Empty returns true if the queue has zero element
 func (q *ConcurrentDeque[X]) Empty() bool {
-	q.inLock.RLock()
-	defer q.inLock.RUnlock()
-	q.outLock.RLock()
-	defer q.outLock.RUnlock()
-	return q.inDeque.Empty() && q.outDeque.Empty()
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.Empty()
 }
 
 // Size returns the total number of elements in the queue.
 func (q *ConcurrentDeque[X]) Size() int {
-	q.inLock.RLock()
-	defer q.inLock.RUnlock()
-	q.outLock.RLock()
-	defer q.outLock.RUnlock()
-	return q.inDeque.Size() + q.outDeque.Size()
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.Size()
 }
 
 // Clear resets the queue.
 func (q *ConcurrentDeque[X]) Clear() {
-	q.inLock.Lock()
-	defer q.inLock.Unlock()
-	q.outLock.Lock()
-	defer q.outLock.Unlock()
-	q.inDeque.Clear()
-	q.outDeque.Clear()
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.deque.Clear()
 }
 
 // Contains checks if the element exists in the deque
 func (q *ConcurrentDeque[X]) Contains(x X) bool {
-	q.inLock.RLock()
-	defer q.inLock.RUnlock()
-	if result := q.inDeque.Contains(x); result {
-		return true
-	}
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.Contains(x)
+}
+
+// ContainsFunc checks if an element satisfying the predicate
+// exists in the deque
+// The predicate is called with the lock held and must not use the deque.
+func (q *ConcurrentDeque[X]) ContainsFunc(predicate func(X) bool) bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.ContainsFunc(predicate)
+}
 
-	q.outLock.RLock()
-	defer q.outLock.RUnlock()
-	return q.outDeque.Contains(x)
+// IndexFunc returns the position from the front of the first element
+// satisfying the predicate, -1 if there is none
+// The predicate is called with the lock held and must not use the deque.
+func (q *ConcurrentDeque[X]) IndexFunc(predicate func(X) bool) int {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.deque.IndexFunc(predicate)
 }
 
 // Reverse reverses the queue
 func (q *ConcurrentDeque[X]) Reverse() {
-	q.fillOutDeque()
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.deque.Reverse()
+}
 
-	q.outLock.Lock()
-	defer q.outLock.Unlock()
-	q.outDeque.Reverse()
+// Snapshot returns the elements in the queue at the time of the call.
+// It takes constant time when the queue is backed by a
+// collection.SnapshotDeque, otherwise the elements are copied into a
+// persistent deque comparing the elements like the queue.
+func (q *ConcurrentDeque[X]) Snapshot() collection.ReadOnlyDeque[X] {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return collection.NewPersistentDequeOf(q.deque)
 }
 
 // ToSlice creates a snapshot of all the elements in the queue
+// from the front to the back
 func (q *ConcurrentDeque[X]) ToSlice() []X {
-	q.inLock.RLock()
-	inSlice := q.inDeque.ToSlice()
-	q.inLock.RUnlock()
+	q.lock.RLock()
+	if deque, ok := q.deque.(collection.SnapshotDeque[X]); ok {
+		// copy the version outside of the lock
+		snapshot := deque.Snapshot()
+		q.lock.RUnlock()
+		return snapshot.ToSlice()
+	}
+	defer q.lock.RUnlock()
+	return q.deque.ToSlice()
+}
 
-	q.outLock.RLock()
-	outSlice := q.outDeque.ToSlice()
-	q.outLock.RUnlock()
+// All returns an iterator over the elements from the front to the back
+// Each iteration walks a snapshot of the queue taken when the iteration
+// starts, so the queue can be modified while iterating and the changes
+// are not reflected until the next iteration.
+func (q *ConcurrentDeque[X]) All() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		q.Snapshot().All()(yield)
+	}
+}
 
-	return append(inSlice, outSlice...)
+// Backward returns an iterator over the elements from the back to the front
+// Each iteration walks a snapshot of the queue taken when the iteration
+// starts.
+func (q *ConcurrentDeque[X]) Backward() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		q.Snapshot().Backward()(yield)
+	}
 }
 
-// Remove removes the element from the queue
+// Enumerate returns an iterator over the position and the element
+// from the front to the back
+// Each iteration walks a snapshot of the queue taken when the iteration
+// starts.
+func (q *ConcurrentDeque[X]) Enumerate() iter.Seq2[int, X] {
+	return func(yield func(int, X) bool) {
+		q.Snapshot().Enumerate()(yield)
+	}
+}
+
+// Remove removes the first occurrence of the element from the front
 // true if removed successfully, false otherwise
 func (q *ConcurrentDeque[X]) Remove(x X) bool {
-	if q.remove(q.inDeque, &q.inLock, x) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.Remove(x)
+}
+
+// RemoveFunc removes the first element satisfying the predicate
+// The predicate is called with the lock held and must not use the deque.
+func (q *ConcurrentDeque[X]) RemoveFunc(predicate func(X) bool) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.RemoveFunc(predicate)
+}
+
+// Rotate rotates the queue n steps to the back
+func (q *ConcurrentDeque[X]) Rotate(n int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.deque.Rotate(n)
+}
+
+// Swap exchanges the elements at the two positions from the front
+func (q *ConcurrentDeque[X]) Swap(i, j int) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.Swap(i, j)
+}
+
+// ExtendBack adds the elements to the back of the queue in order
+// The elements become visible to other goroutines all at once.
+// A bounded queue applies its overflow policy to each element.
+func (q *ConcurrentDeque[X]) ExtendBack(xs ...X) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for _, x := range xs {
+		q.offerBack(x)
+	}
+}
+
+// ExtendFront adds the elements to the front of the queue one at a time
+// The elements become visible to other goroutines all at once.
+// A bounded queue applies its overflow policy to each element.
+func (q *ConcurrentDeque[X]) ExtendFront(xs ...X) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	for _, x := range xs {
+		q.offerFront(x)
+	}
+}
+
+// DrainTo moves up to max elements from the front of the queue to dst,
+// all the elements if max is negative.
+// The lock is held while the elements are added to dst, and a
+// ConcurrentDeque dst is locked as well, so it receives them all at once.
+// Two ConcurrentDeques are always locked in the same order, so draining
+// in opposite directions cannot deadlock. Any other dst must not wait on
+// a lock which may be held while waiting for this queue, e.g. a bounded
+// deque wrapping another ConcurrentDeque.
+// A bounded dst is offered the elements, draining stops at the first
+// element rejected which stays at the front of the queue.
+func (q *ConcurrentDeque[X]) DrainTo(dst collection.Deque[X], max int) int {
+	if other, ok := dst.(*ConcurrentDeque[X]); ok && other != q {
+		first, second := q, other
+		if uintptr(unsafe.Pointer(other)) < uintptr(unsafe.Pointer(q)) {
+			first, second = other, q
+		}
+		first.lock.Lock()
+		defer first.lock.Unlock()
+		second.lock.Lock()
+		defer second.lock.Unlock()
+		return q.drain(other.offerBack, max)
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	offer := func(x X) bool {
+		dst.PushBack(x)
 		return true
 	}
-	return q.remove(q.outDeque, &q.outLock, x)
+	if dst == collection.Deque[X](q) {
+		offer = q.offerBack
+	} else if bounded, ok := dst.(collection.BoundedDeque[X]); ok {
+		offer = bounded.OfferBack
+	}
+	return q.drain(offer, max)
 }
 
-func (q *ConcurrentDeque[X]) remove(deque collection.Deque[X], lock *sync.RWMutex, x X) bool {
-	lock.Lock()
-	defer lock.Unlock()
+// drain must be called with the lock held
+func (q *ConcurrentDeque[X]) drain(offer func(X) bool, max int) int {
+	n := q.deque.Size()
+	if max >= 0 {
+		n = min(n, max)
+	}
+	for i := range n {
+		// the element is popped first to make room when draining into itself
+		x, _ := q.deque.PopFront()
+		if !offer(x) {
+			q.deque.PushFront(x)
+			return i
+		}
+	}
+	return n
+}
+
+// RemoveAll removes every occurrence of the element
+// and returns the number of elements removed
+func (q *ConcurrentDeque[X]) RemoveAll(x X) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.RemoveAll(x)
+}
 
-	return deque.Remove(x)
+// RemoveAllFunc removes every element satisfying the predicate
+// and returns the number of elements removed
+// The predicate is called with the lock held and must not use the deque.
+func (q *ConcurrentDeque[X]) RemoveAllFunc(predicate func(X) bool) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.deque.RemoveAllFunc(predicate)
 }
@@ -1,6 +1,11 @@
 package sync
 
 import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,12 +30,113 @@ func TestConcurrentLinkedDeque(t *testing.T) {
 	runConcurrentDequeTest(t, newDequeFunc)
 }
 
+func TestConcurrentRingDeque(t *testing.T) {
+	newDequeFunc := func() collection.Deque[int] {
+		return NewConcurrentRingDeque[int]()
+	}
+	collectiontest.RunDequeTests(t, newDequeFunc)
+	runConcurrentDequeTest(t, newDequeFunc)
+}
+
+func TestConcurrentSnapshotDeque(t *testing.T) {
+	newDequeFunc := func() collection.Deque[int] {
+		return NewConcurrentSnapshotDeque[int]()
+	}
+	collectiontest.RunDequeTests(t, newDequeFunc)
+	runConcurrentDequeTest(t, newDequeFunc)
+}
+
+func TestConcurrentDequeOf(t *testing.T) {
+	collectiontest.RunDequeTests(t, func() collection.Deque[int] {
+		return NewConcurrentDequeOf(collection.NewLinkedDequeFunc[int](nil))
+	})
+	collectiontest.RunIncomparableDequeTests(t, func(equal func(a, b []int) bool) collection.Deque[[]int] {
+		return NewConcurrentDequeOf(collection.NewRingDequeFunc(equal))
+	})
+	collectiontest.RunBoundedDequeTests(t, func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int] {
+		return NewBoundedConcurrentDequeOf(collection.NewSliceDequeFunc[int](nil), capacity, policy)
+	})
+
+	t.Run("snapshot should compare the elements like the deque", func(t *testing.T) {
+		q := NewConcurrentDequeOf(collection.NewLinkedDequeFunc(slices.Equal[[]int])).(*ConcurrentDeque[[]int])
+		q.ExtendBack([]int{1}, []int{1, 2})
+
+		snapshot := q.Snapshot()
+		require.True(t, snapshot.Contains([]int{1, 2}))
+		require.False(t, snapshot.Contains([]int{2}))
+	})
+}
+
+func TestBoundedConcurrentDeque(t *testing.T) {
+	constructors := []struct {
+		name         string
+		newDequeFunc func(capacity int, policy collection.OverflowPolicy) collection.BoundedDeque[int]
+	}{
+		{"slice", NewBoundedConcurrentSliceDeque[int]},
+		{"linked", NewBoundedConcurrentLinkedDeque[int]},
+		{"ring", NewBoundedConcurrentRingDeque[int]},
+	}
+
+	for _, constructor := range constructors {
+		t.Run(constructor.name, func(t *testing.T) {
+			collectiontest.RunBoundedDequeTests(t, constructor.newDequeFunc)
+
+			t.Run("concurrent producers should not exceed the capacity", func(t *testing.T) {
+				for _, policy := range []collection.OverflowPolicy{
+					collection.OverflowReject,
+					collection.OverflowDropOldest,
+					collection.OverflowDropNewest,
+				} {
+					q := constructor.newDequeFunc(10, policy)
+
+					sizes := make([]int, 5)
+					wg := &sync.WaitGroup{}
+					for i := 0; i < 5; i++ {
+						wg.Add(1)
+						go func(id int) {
+							defer wg.Done()
+							for j := 0; j < 100; j++ {
+								if j%2 == 0 {
+									q.PushBack(id)
+								} else {
+									q.PushFront(id)
+								}
+								sizes[id] = max(sizes[id], q.Size())
+								q.Dequeue()
+							}
+						}(i)
+					}
+					wg.Wait()
+					for _, size := range sizes {
+						require.LessOrEqual(t, size, 10)
+					}
+					require.LessOrEqual(t, q.Size(), 10)
+				}
+			})
+		})
+	}
+
+	t.Run("unbounded deque should never be full", func(t *testing.T) {
+		q := NewConcurrentRingDeque[int]().(*ConcurrentDeque[int])
+		for i := 0; i < 100; i++ {
+			require.True(t, q.OfferBack(i))
+		}
+		require.Equal(t, 0, q.Capacity())
+		require.False(t, q.Full())
+	})
+}
+
 func runConcurrentDequeTest(t *testing.T, newDequeFunc func() collection.Deque[int]) {
 	testCases := []struct {
 		name string
 		test func(t *testing.T, newDequeFunc func() collection.Deque[int])
 	}{
 		{"testConcurrentDeque_Front", testConcurrentDeque_Front},
+		{"testConcurrentDeque_AllSnapshot", testConcurrentDeque_AllSnapshot},
+		{"testConcurrentDeque_AtomicBatch", testConcurrentDeque_AtomicBatch},
+		{"testConcurrentDeque_DrainBothWays", testConcurrentDeque_DrainBothWays},
+		{"testConcurrentDeque_Linearizable", testConcurrentDeque_Linearizable},
+		{"testConcurrentDeque_Snapshot", testConcurrentDeque_Snapshot},
 	}
 
 	for _, testCase := range testCases {
@@ -57,3 +163,255 @@ func testConcurrentDeque_Front(t *testing.T, newDequeFunc func() collection.Dequ
 		q.Dequeue()
 	}
 }
+
+func testConcurrentDeque_AllSnapshot(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Parallel()
+	q := newDequeFunc()
+	for i := 0; i < 3; i++ {
+		q.Enqueue(i)
+	}
+
+	// modifying the queue while iterating should not affect the iteration
+	actual := []int{}
+	for x := range q.All() {
+		q.Enqueue(x + 10)
+		q.Dequeue()
+		actual = append(actual, x)
+	}
+	require.Equal(t, []int{0, 1, 2}, actual)
+	require.Equal(t, []int{10, 11, 12}, q.ToSlice())
+
+	// the next iteration should see the changes
+	actual = []int{}
+	for x := range q.Backward() {
+		actual = append(actual, x)
+	}
+	require.Equal(t, []int{12, 11, 10}, actual)
+}
+
+func testConcurrentDeque_AtomicBatch(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Parallel()
+	src, dst := newDequeFunc(), newDequeFunc()
+
+	// batches of 4 elements are moved around, so a consistent view
+	// always holds a multiple of 4 elements in each deque
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			src.ExtendBack(i, i, i, i)
+			src.DrainTo(dst, 4)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			dst.RemoveAll(i)
+		}
+	}()
+
+	for i := 0; i < 200; i++ {
+		require.Zero(t, len(src.ToSlice())%4, "src should never expose a partial batch")
+		require.Zero(t, len(dst.ToSlice())%4, "dst should never expose a partial batch")
+	}
+	wg.Wait()
+}
+
+func testConcurrentDeque_DrainBothWays(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Parallel()
+	a, b := newDequeFunc(), newDequeFunc()
+	a.ExtendBack(0, 1, 2, 3)
+	b.ExtendBack(4, 5, 6, 7)
+
+	// draining in opposite directions should not deadlock
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			a.DrainTo(b, 2)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			b.DrainTo(a, 2)
+		}
+	}()
+	wg.Wait()
+
+	elements := append(a.ToSlice(), b.ToSlice()...)
+	slices.Sort(elements)
+	require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, elements)
+}
+
+// dequeOperation records one call to a deque in a concurrent history.
+// call and ret are logical timestamps taken right before the call
+// and right after the return.
+type dequeOperation struct {
+	kind   string
+	input  int
+	output int
+	ok     bool
+	call   int64
+	ret    int64
+}
+
+var dequeOperationKinds = []string{
+	"PushBack", "PushFront", "PopBack", "PopFront", "Front", "Back", "Size",
+}
+
+func (op *dequeOperation) apply(q collection.Deque[int]) {
+	switch op.kind {
+	case "PushBack":
+		q.PushBack(op.input)
+	case "PushFront":
+		q.PushFront(op.input)
+	case "PopBack":
+		op.output, op.ok = q.PopBack()
+	case "PopFront":
+		op.output, op.ok = q.PopFront()
+	case "Front":
+		op.output, op.ok = q.Front()
+	case "Back":
+		op.output, op.ok = q.Back()
+	case "Size":
+		op.output, op.ok = q.Size(), true
+	}
+}
+
+// applyToModel applies the operation to a sequential model of the deque
+// and returns the next state of the model, false if the output of the
+// operation cannot be produced by the model.
+func (op *dequeOperation) applyToModel(model []int) ([]int, bool) {
+	switch op.kind {
+	case "PushBack":
+		return append(slices.Clone(model), op.input), true
+	case "PushFront":
+		return append([]int{op.input}, model...), true
+	case "PopBack":
+		if len(model) == 0 {
+			return model, !op.ok
+		}
+		return model[:len(model)-1], op.ok && op.output == model[len(model)-1]
+	case "PopFront":
+		if len(model) == 0 {
+			return model, !op.ok
+		}
+		return model[1:], op.ok && op.output == model[0]
+	case "Front":
+		if len(model) == 0 {
+			return model, !op.ok
+		}
+		return model, op.ok && op.output == model[0]
+	case "Back":
+		if len(model) == 0 {
+			return model, !op.ok
+		}
+		return model, op.ok && op.output == model[len(model)-1]
+	case "Size":
+		return model, op.output == len(model)
+	}
+	return model, false
+}
+
+// linearizable checks if the concurrent history can be explained by
+// applying the operations one at a time to a sequential model, in an
+// order which respects the real time order of the operations.
+func linearizable(history []dequeOperation, done []bool, model []int, count int) bool {
+	if count == len(history) {
+		return true
+	}
+
+	// an operation can only be linearized next if it was called
+	// before every pending operation returned
+	minRet := int64(math.MaxInt64)
+	for i, op := range history {
+		if !done[i] {
+			minRet = min(minRet, op.ret)
+		}
+	}
+
+	for i := range history {
+		op := &history[i]
+		if done[i] || op.call > minRet {
+			continue
+		}
+		next, ok := op.applyToModel(model)
+		if !ok {
+			continue
+		}
+		done[i] = true
+		if linearizable(history, done, next, count+1) {
+			return true
+		}
+		done[i] = false
+	}
+	return false
+}
+
+func testConcurrentDeque_Linearizable(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Parallel()
+	goRoutineCount, operationCount := 3, 4
+
+	for round := 0; round < 200; round++ {
+		q := newDequeFunc()
+		clock := &atomic.Int64{}
+		histories := make([][]dequeOperation, goRoutineCount)
+
+		wg := &sync.WaitGroup{}
+		for g := 0; g < goRoutineCount; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				random := rand.New(rand.NewPCG(uint64(round), uint64(g)))
+				for i := 0; i < operationCount; i++ {
+					op := dequeOperation{
+						kind:  dequeOperationKinds[random.IntN(len(dequeOperationKinds))],
+						input: g*100 + i,
+					}
+					op.call = clock.Add(1)
+					op.apply(q)
+					op.ret = clock.Add(1)
+					histories[g] = append(histories[g], op)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		history := slices.Concat(histories...)
+		require.Truef(t,
+			linearizable(history, make([]bool, len(history)), []int{}, 0),
+			"history is not linearizable: %+v", history,
+		)
+	}
+}
+
+func testConcurrentDeque_Snapshot(t *testing.T, newDequeFunc func() collection.Deque[int]) {
+	t.Parallel()
+	q := newDequeFunc().(*ConcurrentDeque[int])
+	q.ExtendBack(0, 1, 2, 3)
+
+	// changed counts the snapshots which saw a later modification
+	changed := make([]int, 4)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				snapshot := q.Snapshot()
+				expected := snapshot.ToSlice()
+				q.PushFront(j)
+				q.PopBack()
+				if !slices.Equal(expected, snapshot.ToSlice()) || len(expected) != snapshot.Size() {
+					changed[id]++
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, []int{0, 0, 0, 0}, changed, "snapshot should not see later modifications")
+	require.Equal(t, 4, q.Size())
+}
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"context"
+	"sync"
 )
 
 // Future represents an async computation.
@@ -9,6 +10,20 @@ type Future[T any] struct {
 	result T
 	err    error
 	done   chan struct{}
+	once   sync.Once
+}
+
+// newFuture creates a future which settles on the first call to complete
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// complete settles the future, later calls are ignored
+func (f *Future[T]) complete(result T, err error) {
+	f.once.Do(func() {
+		f.result, f.err = result, err
+		close(f.done)
+	})
 }
 
 // NewFuture runs a function asynchronously and returns a Future.
@@ -26,18 +41,17 @@ func NewFutureWithContext[T any](
 	ctx context.Context,
 	fn func(ctx context.Context) (T, error),
 ) *Future[T] {
-	f := &Future[T]{done: make(chan struct{})}
+	f := newFuture[T]()
 
 	go func() {
 		select {
 		// Context canceled before function finishes
 		case <-ctx.Done():
-			f.err = ctx.Err()
-			close(f.done)
+			var zero T
+			f.complete(zero, ctx.Err())
 			return
 		default:
-			f.result, f.err = fn(ctx)
-			close(f.done)
+			f.complete(fn(ctx))
 			return
 		}
 	}()
@@ -0,0 +1,128 @@
+package sync
+
+import (
+	"sync/atomic"
+)
+
+// LockFreeQueue is an unbounded multi-producer multi-consumer FIFO queue.
+// It implements the Michael-Scott algorithm with atomic compare-and-swap,
+// so producers and consumers never block each other on a lock.
+// The garbage collector prevents the ABA problem as a node
+// cannot be reused while any goroutine still references it.
+type LockFreeQueue[X any] struct {
+	// head points to a sentinel node, the front element is head.next
+	head atomic.Pointer[lockFreeNode[X]]
+	tail atomic.Pointer[lockFreeNode[X]]
+	size atomic.Int64
+}
+
+type lockFreeNode[X any] struct {
+	// x is cleared once the node becomes the sentinel
+	// so that the queue does not keep the element alive
+	x    atomic.Pointer[X]
+	next atomic.Pointer[lockFreeNode[X]]
+}
+
+// NewLockFreeQueue creates an empty LockFreeQueue
+func NewLockFreeQueue[X any]() *LockFreeQueue[X] {
+	q := &LockFreeQueue[X]{}
+	sentinel := &lockFreeNode[X]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// Enqueue adds an element to the back of the queue
+func (q *LockFreeQueue[X]) Enqueue(x X) {
+	node := &lockFreeNode[X]{}
+	node.x.Store(&x)
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			// tail moved while reading next, try again
+			continue
+		}
+
+		if next != nil {
+			// tail is lagging behind, help the other producer to advance it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, node) {
+			// failing to advance the tail is fine,
+			// another goroutine has already advanced it
+			q.tail.CompareAndSwap(tail, node)
+			q.size.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue removes an element from the front of the queue
+// a zero value and a false if the queue is empty
+func (q *LockFreeQueue[X]) Dequeue() (X, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			// head moved while reading next, try again
+			continue
+		}
+
+		if next == nil {
+			var zero X
+			return zero, false
+		}
+
+		if head == tail {
+			// tail is lagging behind, help the producer to advance it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		x := next.x.Load()
+		if x == nil {
+			// next has already been dequeued, try again
+			continue
+		}
+
+		// next becomes the new sentinel
+		if q.head.CompareAndSwap(head, next) {
+			next.x.Store(nil)
+			q.size.Add(-1)
+			return *x, true
+		}
+	}
+}
+
+// Front views the first element of the queue
+// a zero value and a false if the queue is empty
+func (q *LockFreeQueue[X]) Front() (X, bool) {
+	for {
+		next := q.head.Load().next.Load()
+		if next == nil {
+			var zero X
+			return zero, false
+		}
+		if x := next.x.Load(); x != nil {
+			return *x, true
+		}
+		// next has already been dequeued, try again
+	}
+}
+
+// Empty returns true if the queue has zero element
+func (q *LockFreeQueue[X]) Empty() bool {
+	return q.head.Load().next.Load() == nil
+}
+
+// Size returns the total number of elements in the queue.
+// The size is only a hint while other goroutines are
+// enqueuing or dequeuing concurrently.
+func (q *LockFreeQueue[X]) Size() int {
+	// a dequeue may be counted before its matching enqueue
+	return int(max(q.size.Load(), 0))
+}
@@ -0,0 +1,151 @@
+package sync
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFreeQueue(t *testing.T) {
+	t.Run("dequeue from an empty queue should be false", func(t *testing.T) {
+		q := NewLockFreeQueue[int]()
+		require.True(t, q.Empty())
+
+		x, ok := q.Dequeue()
+		require.False(t, ok, "Dequeue on an empty queue should return false")
+		require.Equal(t, 0, x)
+
+		_, ok = q.Front()
+		require.False(t, ok, "Front on an empty queue should return false")
+	})
+
+	t.Run("queue should be FIFO", func(t *testing.T) {
+		q := NewLockFreeQueue[int]()
+		for i := 0; i < 10; i++ {
+			q.Enqueue(i)
+		}
+		require.Equal(t, 10, q.Size())
+
+		front, ok := q.Front()
+		require.True(t, ok, "Front should return true for non-empty queue")
+		require.Equal(t, 0, front)
+
+		for i := 0; i < 10; i++ {
+			x, ok := q.Dequeue()
+			require.True(t, ok, "Dequeue should return true for non-empty queue")
+			require.Equal(t, i, x)
+		}
+		require.True(t, q.Empty())
+		require.Equal(t, 0, q.Size())
+	})
+
+	t.Run("the sentinel should not keep the dequeued element", func(t *testing.T) {
+		q := NewLockFreeQueue[*int]()
+		x := 1
+		q.Enqueue(&x)
+
+		actual, ok := q.Dequeue()
+		require.True(t, ok)
+		require.Same(t, &x, actual)
+		require.Nil(t, q.head.Load().x.Load(), "the new sentinel should drop the element")
+	})
+
+	t.Run("concurrent producers and consumers should not lose elements", func(t *testing.T) {
+		q := NewLockFreeQueue[int]()
+		producers, consumers, perProducer := 4, 4, 1000
+
+		producerWg := &sync.WaitGroup{}
+		for i := 0; i < producers; i++ {
+			producerWg.Add(1)
+			go func(id int) {
+				defer producerWg.Done()
+				for j := 0; j < perProducer; j++ {
+					q.Enqueue(id*perProducer + j)
+				}
+			}(i)
+		}
+
+		done := make(chan struct{})
+		results := make([][]int, consumers)
+		consumerWg := &sync.WaitGroup{}
+		for i := 0; i < consumers; i++ {
+			consumerWg.Add(1)
+			go func(id int) {
+				defer consumerWg.Done()
+				for {
+					x, ok := q.Dequeue()
+					if ok {
+						results[id] = append(results[id], x)
+						continue
+					}
+					select {
+					case <-done:
+						// drain what is left after the producers finished
+						if q.Empty() {
+							return
+						}
+					default:
+					}
+				}
+			}(i)
+		}
+
+		producerWg.Wait()
+		close(done)
+		consumerWg.Wait()
+
+		seen := make([]bool, producers*perProducer)
+		for _, result := range results {
+			// each consumer should see the elements of a producer in order
+			last := make([]int, producers)
+			for i := range last {
+				last[i] = -1
+			}
+			for _, x := range result {
+				require.False(t, seen[x], "element %d dequeued twice", x)
+				seen[x] = true
+
+				producer := x / perProducer
+				require.Greater(t, x, last[producer], "elements of a producer should be FIFO")
+				last[producer] = x
+			}
+		}
+		for x, ok := range seen {
+			require.True(t, ok, "element %d was lost", x)
+		}
+	})
+}
+
+type benchmarkQueue interface {
+	Enqueue(x int)
+	Dequeue() (int, bool)
+}
+
+func benchmarkQueueParallel(b *testing.B, q benchmarkQueue) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%2 == 0 {
+				q.Enqueue(i)
+			} else {
+				q.Dequeue()
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkLockFreeQueue(b *testing.B) {
+	benchmarkQueueParallel(b, NewLockFreeQueue[int]())
+}
+
+func BenchmarkConcurrentSliceDeque(b *testing.B) {
+	benchmarkQueueParallel(b, NewConcurrentSliceDeque[int]())
+}
+
+func BenchmarkConcurrentLinkedDeque(b *testing.B) {
+	benchmarkQueueParallel(b, NewConcurrentLinkedDeque[int]())
+}
@@ -0,0 +1,89 @@
+package sync
+
+import (
+	"sync"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// ConcurrentPriorityQueue is a priority queue guarded by a read-write lock
+// so that it can be shared by multiple goroutines.
+// Every operation is linearizable like ConcurrentDeque.
+type ConcurrentPriorityQueue[X any] struct {
+	lock  sync.RWMutex
+	queue collection.PriorityQueue[X]
+}
+
+// NewConcurrentPriorityQueue creates a ConcurrentPriorityQueue backed by
+// a binary heap which serves the least element according to less first
+func NewConcurrentPriorityQueue[X any](less func(a, b X) bool) collection.PriorityQueue[X] {
+	return &ConcurrentPriorityQueue[X]{
+		queue: collection.NewPriorityQueue(less),
+	}
+}
+
+// NewConcurrentPriorityQueueOf creates a ConcurrentPriorityQueue guarding
+// the queue. The queue should no longer be used directly.
+func NewConcurrentPriorityQueueOf[X any](queue collection.PriorityQueue[X]) collection.PriorityQueue[X] {
+	return &ConcurrentPriorityQueue[X]{
+		queue: queue,
+	}
+}
+
+// Push adds an element to the queue
+func (q *ConcurrentPriorityQueue[X]) Push(x X) *collection.Handle[X] {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.queue.Push(x)
+}
+
+// Pop removes the least element from the queue
+func (q *ConcurrentPriorityQueue[X]) Pop() (X, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.queue.Pop()
+}
+
+// Peek views the least element of the queue
+func (q *ConcurrentPriorityQueue[X]) Peek() (X, bool) {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.queue.Peek()
+}
+
+// Update replaces the element referred to by the handle
+// false if the element is no longer in the queue
+func (q *ConcurrentPriorityQueue[X]) Update(handle *collection.Handle[X], x X) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.queue.Update(handle, x)
+}
+
+// Remove removes the element referred to by the handle
+// a zero value and a false if the element is no longer in the queue
+func (q *ConcurrentPriorityQueue[X]) Remove(handle *collection.Handle[X]) (X, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.queue.Remove(handle)
+}
+
+// Len returns the total number of elements in the queue
+func (q *ConcurrentPriorityQueue[X]) Len() int {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.queue.Len()
+}
+
+// Empty returns true if the queue has zero element
+func (q *ConcurrentPriorityQueue[X]) Empty() bool {
+	q.lock.RLock()
+	defer q.lock.RUnlock()
+	return q.queue.Empty()
+}
+
+// Clear removes all elements from the queue
+func (q *ConcurrentPriorityQueue[X]) Clear() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.queue.Clear()
+}
@@ -0,0 +1,51 @@
+package sync
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+	"github.com/kevin-ip/go-handy/collectiontest"
+)
+
+func TestConcurrentPriorityQueue(t *testing.T) {
+	collectiontest.RunPriorityQueueTests(t, NewConcurrentPriorityQueue[int])
+	collectiontest.RunPriorityQueueTests(t, func(less func(a, b int) bool) collection.PriorityQueue[int] {
+		return NewConcurrentPriorityQueueOf(collection.NewPriorityQueue(less))
+	})
+
+	t.Run("concurrent producers and consumers should pop every element", func(t *testing.T) {
+		q := NewConcurrentPriorityQueue(func(a, b int) bool { return a < b })
+
+		wg := &sync.WaitGroup{}
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				for j := 0; j < 250; j++ {
+					handle := q.Push(id*1000 + j)
+					if j%5 == 0 {
+						q.Update(handle, -(id*1000 + j))
+					}
+					if j%7 == 0 {
+						q.Remove(handle)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		popped, previous := 0, -1<<31
+		for !q.Empty() {
+			x, ok := q.Pop()
+			require.True(t, ok)
+			require.LessOrEqual(t, previous, x, "Pop should return the least element first")
+			previous = x
+			popped++
+		}
+		// 36 of the 250 elements of each producer are removed
+		require.Equal(t, 4*(250-36), popped)
+	})
+}
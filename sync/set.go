@@ -0,0 +1,186 @@
+package sync
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// ConcurrentSet is a set split into shards, each guarded by its own
+// read-write lock, so that goroutines using different elements
+// rarely contend on the same lock.
+//
+// Add, Remove and Contains are linearizable. Len, All, ToSlice and the
+// set algebra visit the shards one at a time, so they are not a snapshot
+// of the whole set while other goroutines modify it.
+type ConcurrentSet[X comparable] struct {
+	hasher shardHasher[X]
+	shards []setShard[X]
+}
+
+type setShard[X comparable] struct {
+	lock sync.RWMutex
+	set  collection.Set[X]
+}
+
+// NewConcurrentSet creates an empty ConcurrentSet
+func NewConcurrentSet[X comparable](options ...ShardOption) collection.Set[X] {
+	return newConcurrentSet[X](newShardSettings(options).shardCount)
+}
+
+func newConcurrentSet[X comparable](shardCount int) *ConcurrentSet[X] {
+	s := &ConcurrentSet[X]{
+		hasher: newShardHasher[X](shardCount),
+		shards: make([]setShard[X], shardCount),
+	}
+	for i := range s.shards {
+		s.shards[i].set = collection.NewSet[X]()
+	}
+	return s
+}
+
+func (s *ConcurrentSet[X]) shard(x X) *setShard[X] {
+	return &s.shards[s.hasher.index(x)]
+}
+
+// Add adds an element to the set
+// false if the element is already in the set
+func (s *ConcurrentSet[X]) Add(x X) bool {
+	shard := s.shard(x)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.set.Add(x)
+}
+
+// Remove removes an element from the set
+// false if the element is not in the set
+func (s *ConcurrentSet[X]) Remove(x X) bool {
+	shard := s.shard(x)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	return shard.set.Remove(x)
+}
+
+// Contains checks if the element exists in the set
+func (s *ConcurrentSet[X]) Contains(x X) bool {
+	shard := s.shard(x)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.set.Contains(x)
+}
+
+// Len returns the total number of elements in the set
+func (s *ConcurrentSet[X]) Len() int {
+	total := 0
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.lock.RLock()
+		total += shard.set.Len()
+		shard.lock.RUnlock()
+	}
+	return total
+}
+
+// Empty returns true if the set has zero element
+func (s *ConcurrentSet[X]) Empty() bool {
+	return s.Len() == 0
+}
+
+// Clear removes all elements from the set
+func (s *ConcurrentSet[X]) Clear() {
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.lock.Lock()
+		shard.set.Clear()
+		shard.lock.Unlock()
+	}
+}
+
+// Union returns the elements in either set as a new ConcurrentSet
+func (s *ConcurrentSet[X]) Union(other collection.Set[X]) collection.Set[X] {
+	union := newConcurrentSet[X](len(s.shards))
+	for x := range s.All() {
+		union.Add(x)
+	}
+	for x := range other.All() {
+		union.Add(x)
+	}
+	return union
+}
+
+// Intersection returns the elements in both sets as a new ConcurrentSet
+func (s *ConcurrentSet[X]) Intersection(other collection.Set[X]) collection.Set[X] {
+	intersection := newConcurrentSet[X](len(s.shards))
+	for x := range s.All() {
+		if other.Contains(x) {
+			intersection.Add(x)
+		}
+	}
+	return intersection
+}
+
+// Difference returns the elements in this set but not in the other
+// as a new ConcurrentSet
+func (s *ConcurrentSet[X]) Difference(other collection.Set[X]) collection.Set[X] {
+	difference := newConcurrentSet[X](len(s.shards))
+	for x := range s.All() {
+		if !other.Contains(x) {
+			difference.Add(x)
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns the elements in exactly one of the sets
+// as a new ConcurrentSet
+func (s *ConcurrentSet[X]) SymmetricDifference(other collection.Set[X]) collection.Set[X] {
+	difference := s.Difference(other)
+	for x := range other.All() {
+		if !s.Contains(x) {
+			difference.Add(x)
+		}
+	}
+	return difference
+}
+
+// IsSubset returns true if every element of this set is in the other
+func (s *ConcurrentSet[X]) IsSubset(other collection.Set[X]) bool {
+	for x := range s.All() {
+		if !other.Contains(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the elements in no particular order
+// Each shard is copied when the iteration reaches it, so the loop body
+// may use the set.
+func (s *ConcurrentSet[X]) All() iter.Seq[X] {
+	return func(yield func(X) bool) {
+		for i := range s.shards {
+			for _, x := range s.shardSlice(i) {
+				if !yield(x) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ToSlice returns the elements in no particular order
+func (s *ConcurrentSet[X]) ToSlice() []X {
+	slice := []X{}
+	for i := range s.shards {
+		slice = append(slice, s.shardSlice(i)...)
+	}
+	return slice
+}
+
+func (s *ConcurrentSet[X]) shardSlice(i int) []X {
+	shard := &s.shards[i]
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	return shard.set.ToSlice()
+}
@@ -0,0 +1,59 @@
+package sync
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collection"
+	"github.com/kevin-ip/go-handy/collectiontest"
+)
+
+func TestConcurrentSet(t *testing.T) {
+	collectiontest.RunSetTests(t, func() collection.Set[int] {
+		return NewConcurrentSet[int]()
+	})
+	collectiontest.RunSetTests(t, func() collection.Set[int] {
+		return NewConcurrentSet[int](WithShardCount(1))
+	})
+
+	t.Run("shard count should be rounded up to a power of two", func(t *testing.T) {
+		for _, testCase := range []struct{ count, expected int }{
+			{-1, 1}, {0, 1}, {1, 1}, {3, 4}, {16, 16}, {17, 32},
+		} {
+			set := NewConcurrentSet[int](WithShardCount(testCase.count)).(*ConcurrentSet[int])
+			require.Len(t, set.shards, testCase.expected, "WithShardCount(%d)", testCase.count)
+		}
+	})
+
+	t.Run("concurrent writers should not lose elements", func(t *testing.T) {
+		set := NewConcurrentSet[int](WithShardCount(4))
+
+		// missing counts the elements a goroutine did not find after adding them
+		missing := make([]int, 8)
+		wg := &sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				for j := 0; j < 500; j++ {
+					// every goroutine adds the same elements and removes its own
+					set.Add(j)
+					set.Add(-(id*1000 + j + 1))
+					set.Remove(-(id*1000 + j + 1))
+					if !set.Contains(j) {
+						missing[id]++
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		require.Equal(t, make([]int, 8), missing)
+
+		require.Equal(t, 500, set.Len())
+		for x := range set.All() {
+			require.GreaterOrEqual(t, x, 0)
+		}
+	})
+}
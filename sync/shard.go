@@ -0,0 +1,136 @@
+package sync
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"reflect"
+	"runtime"
+)
+
+// shardSettings configures the collections split into shards,
+// each shard being guarded by its own lock
+type shardSettings struct {
+	shardCount int
+}
+
+func newShardSettings(options []ShardOption) *shardSettings {
+	// Default settings
+	settings := &shardSettings{
+		shardCount: 4 * runtime.GOMAXPROCS(0),
+	}
+	for _, option := range options {
+		option.Apply(settings)
+	}
+	// a power of two turns the modulo into a mask
+	settings.shardCount = 1 << bits.Len(uint(max(settings.shardCount, 1)-1))
+	return settings
+}
+
+type ShardOption interface {
+	Apply(*shardSettings)
+}
+
+// WithShardCount sets the number of shards, rounded up to a power of two.
+// More shards reduce the contention between goroutines using different
+// elements at the cost of memory. It defaults to four times GOMAXPROCS.
+func WithShardCount(count int) ShardOption {
+	return withShardCount(count)
+}
+
+type withShardCount int
+
+func (w withShardCount) Apply(settings *shardSettings) {
+	settings.shardCount = int(w)
+}
+
+// shardHasher picks the shard of an element
+type shardHasher[X comparable] struct {
+	seed maphash.Seed
+	mask uint64
+}
+
+func newShardHasher[X comparable](shardCount int) shardHasher[X] {
+	return shardHasher[X]{
+		seed: maphash.MakeSeed(),
+		mask: uint64(shardCount - 1),
+	}
+}
+
+// index returns the position of the shard holding the element
+func (h shardHasher[X]) index(x X) int {
+	var hash maphash.Hash
+	hash.SetSeed(h.seed)
+
+	// the common keys skip reflection
+	switch key := any(x).(type) {
+	case string:
+		hash.WriteString(key)
+	case int:
+		writeUint64(&hash, uint64(key))
+	case int64:
+		writeUint64(&hash, uint64(key))
+	case uint64:
+		writeUint64(&hash, key)
+	default:
+		writeValue(&hash, reflect.ValueOf(&x).Elem())
+	}
+	return int(hash.Sum64() & h.mask)
+}
+
+// writeValue hashes a comparable value so that values equal with the ==
+// operator write the same bytes
+func writeValue(hash *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			hash.WriteByte(1)
+		} else {
+			hash.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(hash, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(hash, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(hash, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(hash, real(v.Complex()))
+		writeFloat(hash, imag(v.Complex()))
+	case reflect.String:
+		hash.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(hash, uint64(v.Pointer()))
+	case reflect.Interface:
+		if !v.IsNil() {
+			writeValue(hash, v.Elem())
+		}
+	case reflect.Array:
+		for i := range v.Len() {
+			writeValue(hash, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			// blank fields are ignored by the == operator
+			if v.Type().Field(i).Name != "_" {
+				writeValue(hash, v.Field(i))
+			}
+		}
+	}
+}
+
+// writeFloat hashes a float so that 0 and -0 write the same bytes
+func writeFloat(hash *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0
+	}
+	writeUint64(hash, math.Float64bits(f))
+}
+
+// writeUint64 hashes the bytes of x
+func writeUint64(hash *maphash.Hash, x uint64) {
+	var bytes [8]byte
+	binary.LittleEndian.PutUint64(bytes[:], x)
+	hash.Write(bytes[:])
+}
@@ -0,0 +1,42 @@
+package sync
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardHasher(t *testing.T) {
+	type key struct {
+		name  string
+		value float64
+		ptr   *int
+		any   any
+		_     int
+	}
+
+	t.Run("equal elements should be in the same shard", func(t *testing.T) {
+		hasher := newShardHasher[key](1024)
+		x := 1
+		a := key{name: "a", value: 0, ptr: &x, any: 1}
+		b := key{name: "a", value: math.Copysign(0, -1), ptr: &x, any: 1}
+		require.Equal(t, a, b)
+		require.Equal(t, hasher.index(a), hasher.index(b))
+	})
+
+	t.Run("elements should spread over the shards", func(t *testing.T) {
+		ints := newShardHasher[int](16)
+		strings := newShardHasher[string](16)
+		keys := newShardHasher[key](16)
+		intShards, stringShards, keyShards := map[int]bool{}, map[int]bool{}, map[int]bool{}
+		for i := 0; i < 1000; i++ {
+			intShards[ints.index(i)] = true
+			stringShards[strings.index(string(rune('a'+i%26))+string(rune('a'+i/26)))] = true
+			keyShards[keys.index(key{value: float64(i)})] = true
+		}
+		require.Len(t, intShards, 16)
+		require.Len(t, stringShards, 16)
+		require.Len(t, keyShards, 16)
+	})
+}
@@ -0,0 +1,149 @@
+package sync
+
+import (
+	"sync"
+)
+
+// ShardedMap is a map split into shards, each guarded by its own
+// read-write lock, so that goroutines using different keys rarely contend
+// on the same lock. Unlike sync.Map, it stays fast when the keys are
+// frequently written.
+//
+// Load, Store, LoadOrStore, Compute and Delete are linearizable.
+// Len and Range visit the shards one at a time, so they are not a snapshot
+// of the whole map while other goroutines modify it.
+type ShardedMap[K comparable, V any] struct {
+	hasher shardHasher[K]
+	shards []mapShard[K, V]
+}
+
+type mapShard[K comparable, V any] struct {
+	lock    sync.RWMutex
+	entries map[K]V
+	// padding keeps the locks of neighbour shards on different cache lines
+	_ [64]byte
+}
+
+// NewShardedMap creates an empty ShardedMap
+func NewShardedMap[K comparable, V any](options ...ShardOption) *ShardedMap[K, V] {
+	shardCount := newShardSettings(options).shardCount
+	m := &ShardedMap[K, V]{
+		hasher: newShardHasher[K](shardCount),
+		shards: make([]mapShard[K, V], shardCount),
+	}
+	for i := range m.shards {
+		m.shards[i].entries = map[K]V{}
+	}
+	return m
+}
+
+func (m *ShardedMap[K, V]) shard(key K) *mapShard[K, V] {
+	return &m.shards[m.hasher.index(key)]
+}
+
+// Load returns the value of the key
+// a zero value and a false if the key is not in the map
+func (m *ShardedMap[K, V]) Load(key K) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+	value, ok := shard.entries[key]
+	return value, ok
+}
+
+// Store sets the value of the key
+func (m *ShardedMap[K, V]) Store(key K, value V) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	shard.entries[key] = value
+}
+
+// LoadOrStore returns the existing value of the key if present,
+// otherwise it stores and returns the given value.
+// true if the value was loaded, false if stored.
+func (m *ShardedMap[K, V]) LoadOrStore(key K, value V) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	if actual, ok := shard.entries[key]; ok {
+		return actual, true
+	}
+	shard.entries[key] = value
+	return value, false
+}
+
+// Compute atomically replaces the value of the key with the result of
+// remap, which receives the current value and whether the key is present.
+// The key is deleted if remap returns false for keep.
+// It returns the new value and whether the key is present afterwards.
+// remap is called with the shard locked and must not use the map.
+func (m *ShardedMap[K, V]) Compute(
+	key K,
+	remap func(value V, loaded bool) (newValue V, keep bool),
+) (V, bool) {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	value, loaded := shard.entries[key]
+	newValue, keep := remap(value, loaded)
+	if !keep {
+		delete(shard.entries, key)
+		var zero V
+		return zero, false
+	}
+	shard.entries[key] = newValue
+	return newValue, true
+}
+
+// Delete removes the key from the map
+// false if the key is not in the map
+func (m *ShardedMap[K, V]) Delete(key K) bool {
+	shard := m.shard(key)
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+	if _, ok := shard.entries[key]; !ok {
+		return false
+	}
+	delete(shard.entries, key)
+	return true
+}
+
+// Range calls f for each key and value in no particular order
+// until f returns false. Each shard is copied when Range reaches it,
+// so f may use the map.
+func (m *ShardedMap[K, V]) Range(f func(key K, value V) bool) {
+	type entry struct {
+		key   K
+		value V
+	}
+
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.lock.RLock()
+		entries := make([]entry, 0, len(shard.entries))
+		for key, value := range shard.entries {
+			entries = append(entries, entry{key, value})
+		}
+		shard.lock.RUnlock()
+
+		for _, e := range entries {
+			if !f(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the total number of keys in the map
+func (m *ShardedMap[K, V]) Len() int {
+	total := 0
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.lock.RLock()
+		total += len(shard.entries)
+		shard.lock.RUnlock()
+	}
+	return total
+}
@@ -0,0 +1,175 @@
+package sync
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedMap(t *testing.T) {
+	t.Run("Load, Store and Delete", func(t *testing.T) {
+		m := NewShardedMap[string, int]()
+		_, ok := m.Load("a")
+		require.False(t, ok, "Load on an empty map should return false")
+
+		m.Store("a", 1)
+		m.Store("b", 2)
+		m.Store("a", 10)
+		actual, ok := m.Load("a")
+		require.True(t, ok)
+		require.Equal(t, 10, actual, "Store should replace the value")
+		require.Equal(t, 2, m.Len())
+
+		require.True(t, m.Delete("a"), "Delete should return true for an existing key")
+		require.False(t, m.Delete("a"), "Delete should return false once deleted")
+		_, ok = m.Load("a")
+		require.False(t, ok)
+		require.Equal(t, 1, m.Len())
+	})
+
+	t.Run("LoadOrStore should keep the existing value", func(t *testing.T) {
+		m := NewShardedMap[string, int]()
+		actual, loaded := m.LoadOrStore("a", 1)
+		require.False(t, loaded)
+		require.Equal(t, 1, actual)
+
+		actual, loaded = m.LoadOrStore("a", 2)
+		require.True(t, loaded)
+		require.Equal(t, 1, actual)
+	})
+
+	t.Run("Compute should add, update and delete", func(t *testing.T) {
+		m := NewShardedMap[string, int]()
+		increment := func(value int, loaded bool) (int, bool) {
+			return value + 1, true
+		}
+
+		actual, ok := m.Compute("a", increment)
+		require.True(t, ok)
+		require.Equal(t, 1, actual, "Compute should receive the zero value for a missing key")
+		actual, _ = m.Compute("a", increment)
+		require.Equal(t, 2, actual)
+
+		actual, ok = m.Compute("a", func(value int, loaded bool) (int, bool) {
+			require.True(t, loaded)
+			require.Equal(t, 2, value)
+			return 0, false
+		})
+		require.False(t, ok, "Compute should delete the key when not kept")
+		require.Equal(t, 0, actual)
+		require.Equal(t, 0, m.Len())
+
+		_, ok = m.Compute("b", func(value int, loaded bool) (int, bool) {
+			return value, loaded
+		})
+		require.False(t, ok, "Compute should not add a key when not kept")
+		require.Equal(t, 0, m.Len())
+	})
+
+	t.Run("Range should visit every key until stopped", func(t *testing.T) {
+		m := NewShardedMap[int, int](WithShardCount(4))
+		expected := map[int]int{}
+		for i := 0; i < 100; i++ {
+			m.Store(i, i*i)
+			expected[i] = i * i
+		}
+
+		actual := map[int]int{}
+		m.Range(func(key, value int) bool {
+			actual[key] = value
+			// the map may be used while ranging
+			m.Store(key, -value)
+			return true
+		})
+		require.Equal(t, expected, actual)
+
+		visited := 0
+		m.Range(func(int, int) bool {
+			visited++
+			return visited < 10
+		})
+		require.Equal(t, 10, visited, "Range should stop when f returns false")
+	})
+
+	t.Run("concurrent Compute should not lose updates", func(t *testing.T) {
+		m := NewShardedMap[int, int]()
+		wg := &sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					m.Compute(j%10, func(value int, _ bool) (int, bool) {
+						return value + 1, true
+					})
+				}
+			}()
+		}
+		wg.Wait()
+
+		actual := map[int]int{}
+		m.Range(func(key, value int) bool {
+			actual[key] = value
+			return true
+		})
+		require.Len(t, actual, 10)
+		for key, value := range actual {
+			require.Equal(t, 800, value, "key %d should be computed by every goroutine", key)
+		}
+	})
+}
+
+// benchmarkMap is the part of the map API shared by ShardedMap and sync.Map
+type benchmarkMap interface {
+	Load(key int) (int, bool)
+	Store(key int, value int)
+}
+
+// syncMap adapts sync.Map to benchmarkMap
+type syncMap struct {
+	m sync.Map
+}
+
+func (s *syncMap) Load(key int) (int, bool) {
+	value, ok := s.m.Load(key)
+	if !ok {
+		return 0, false
+	}
+	return value.(int), true
+}
+
+func (s *syncMap) Store(key int, value int) {
+	s.m.Store(key, value)
+}
+
+// benchmarkMapWriteHeavy stores 3 times out of 4 over a fixed set of keys
+func benchmarkMapWriteHeavy(b *testing.B, m benchmarkMap) {
+	const keys = 1 << 12
+	for i := 0; i < keys; i++ {
+		m.Store(i, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := (i * 7919) % keys
+			if i%4 == 0 {
+				m.Load(key)
+			} else {
+				m.Store(key, i)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedMap_WriteHeavy(b *testing.B) {
+	benchmarkMapWriteHeavy(b, NewShardedMap[int, int]())
+}
+
+func BenchmarkSyncMap_WriteHeavy(b *testing.B) {
+	benchmarkMapWriteHeavy(b, &syncMap{})
+}
@@ -0,0 +1,165 @@
+package sync
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+
+	"github.com/kevin-ip/go-handy/collection"
+)
+
+// ConcurrentSortedMap is a sorted map guarded by a read-write lock
+// so that it can be shared by multiple goroutines.
+// Every operation is linearizable like ConcurrentDeque, and the iterators
+// go through a snapshot of the keys taken when the iteration starts.
+type ConcurrentSortedMap[K any, V any] struct {
+	lock    sync.RWMutex
+	entries collection.SortedMap[K, V]
+}
+
+// NewConcurrentSortedMap creates a ConcurrentSortedMap backed by a skip list
+// ordering the keys naturally
+func NewConcurrentSortedMap[K cmp.Ordered, V any]() collection.SortedMap[K, V] {
+	return &ConcurrentSortedMap[K, V]{
+		entries: collection.NewSortedMap[K, V](),
+	}
+}
+
+// NewConcurrentSortedMapFunc creates a ConcurrentSortedMap backed by
+// a skip list ordering the keys with the comparator
+func NewConcurrentSortedMapFunc[K any, V any](compare func(a, b K) int) collection.SortedMap[K, V] {
+	return &ConcurrentSortedMap[K, V]{
+		entries: collection.NewSortedMapFunc[K, V](compare),
+	}
+}
+
+// Get returns the value of the key
+func (m *ConcurrentSortedMap[K, V]) Get(key K) (V, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Get(key)
+}
+
+// Set sets the value of the key
+func (m *ConcurrentSortedMap[K, V]) Set(key K, value V) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.entries.Set(key, value)
+}
+
+// Delete removes the key from the map
+// false if the key is not in the map
+func (m *ConcurrentSortedMap[K, V]) Delete(key K) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.entries.Delete(key)
+}
+
+// Contains checks if the key exists in the map
+func (m *ConcurrentSortedMap[K, V]) Contains(key K) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Contains(key)
+}
+
+// Len returns the total number of keys in the map
+func (m *ConcurrentSortedMap[K, V]) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Len()
+}
+
+// Empty returns true if the map has zero key
+func (m *ConcurrentSortedMap[K, V]) Empty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Empty()
+}
+
+// Clear removes all keys from the map
+func (m *ConcurrentSortedMap[K, V]) Clear() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.entries.Clear()
+}
+
+// Min views the least key and its value
+func (m *ConcurrentSortedMap[K, V]) Min() (K, V, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Min()
+}
+
+// Max views the greatest key and its value
+func (m *ConcurrentSortedMap[K, V]) Max() (K, V, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Max()
+}
+
+// PopMin removes the least key and returns it with its value
+func (m *ConcurrentSortedMap[K, V]) PopMin() (K, V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.entries.PopMin()
+}
+
+// PopMax removes the greatest key and returns it with its value
+func (m *ConcurrentSortedMap[K, V]) PopMax() (K, V, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.entries.PopMax()
+}
+
+// Floor views the greatest key less than or equal to the key
+func (m *ConcurrentSortedMap[K, V]) Floor(key K) (K, V, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Floor(key)
+}
+
+// Ceiling views the least key greater than or equal to the key
+func (m *ConcurrentSortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.entries.Ceiling(key)
+}
+
+// Range returns an iterator over a snapshot of the keys from "from"
+// included to "to" excluded in ascending order
+func (m *ConcurrentSortedMap[K, V]) Range(from, to K) iter.Seq2[K, V] {
+	return m.snapshot(func() iter.Seq2[K, V] {
+		return m.entries.Range(from, to)
+	})
+}
+
+// All returns an iterator over a snapshot of the keys in ascending order
+func (m *ConcurrentSortedMap[K, V]) All() iter.Seq2[K, V] {
+	return m.snapshot(m.entries.All)
+}
+
+// Backward returns an iterator over a snapshot of the keys
+// in descending order
+func (m *ConcurrentSortedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return m.snapshot(m.entries.Backward)
+}
+
+// snapshot copies the entries of the iterator under the read lock
+// when the iteration starts, so the loop body may use the map
+func (m *ConcurrentSortedMap[K, V]) snapshot(seq func() iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.lock.RLock()
+		keys, values := []K{}, []V{}
+		for key, value := range seq() {
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+		m.lock.RUnlock()
+
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package sync
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kevin-ip/go-handy/collectiontest"
+)
+
+func TestConcurrentSortedMap(t *testing.T) {
+	collectiontest.RunSortedMapTests(t, NewConcurrentSortedMap[int, string])
+
+	t.Run("the loop body may use the map", func(t *testing.T) {
+		m := NewConcurrentSortedMap[int, int]()
+		for i := 0; i < 5; i++ {
+			m.Set(i, i)
+		}
+		for key := range m.Range(1, 4) {
+			m.Set(key+10, key)
+		}
+		require.Equal(t, []int{0, 1, 2, 3, 4, 11, 12, 13}, keysOf(m.All()))
+	})
+
+	t.Run("concurrent writers should keep the keys sorted", func(t *testing.T) {
+		m := NewConcurrentSortedMap[int, int]()
+		// unsorted counts the iterations which saw the keys out of order
+		unsorted := make([]int, 4)
+		wg := &sync.WaitGroup{}
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				for j := 0; j < 250; j++ {
+					m.Set(j*4+id, id)
+					if j%2 == 0 {
+						m.PopMin()
+					}
+					if !slices.IsSorted(keysOf(m.All())) {
+						unsorted[id]++
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		require.Equal(t, make([]int, 4), unsorted)
+		require.Equal(t, 500, m.Len())
+	})
+}
+
+// keysOf collects the keys of the iterator
+func keysOf[K any, V any](seq iter.Seq2[K, V]) []K {
+	keys := []K{}
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -103,6 +103,7 @@ func (w withGoRoutineCount) Apply(settings *concurrentMapSettings) {
 // Partial result may be returned if some of the work are able
 // to complete successfully. If there is an error from one of the work,
 // the result from the work will not be included in the return value.
+// Use ShardedMap for a map data structure shared by goroutines.
 func ConcurrentMap[X any, Y any](
 	ctx context.Context,
 	inputs []X,
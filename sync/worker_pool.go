@@ -3,40 +3,358 @@ package sync
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kevin-ip/go-handy/collection"
 )
 
 type Task func()
 
+// PanicError is a panic recovered from a task
+type PanicError struct {
+	// Value is the value passed to panic
+	Value any
+	// Stack is the stack trace of the goroutine when the task panicked
+	Stack []byte
+}
+
+// newPanicError captures the stack of the panicking goroutine,
+// it must be called by the deferred function recovering the panic
+func newPanicError(value any) *PanicError {
+	return &PanicError{Value: value, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", e.Value)
+}
+
+// PanicHandler is called by the worker which recovered a panic from a task.
+// It must not panic itself.
+type PanicHandler func(err *PanicError)
+
+// TaskInfo describes a task to the OnTaskStart and OnTaskEnd hooks
+type TaskInfo struct {
+	// Pool is the name of the pool
+	Pool      string
+	Priority  int
+	Submitted time.Time
+	Started   time.Time
+
+	// Duration and Err are only set once the task ends.
+	// Err is a *PanicError if the task panicked, ErrTaskTimeout if it ran
+	// longer than the task timeout, nil otherwise.
+	Duration time.Duration
+	Err      error
+}
+
+// ErrPoolClosed is returned when submitting to or resizing a closed pool
+var ErrPoolClosed = errors.New("worker pool has been closed")
+
+// ErrQueueFull is returned when the task queue has no space for the task
+var ErrQueueFull = errors.New("task queue is full")
+
+// ErrTaskTimeout reports a task running longer than the task timeout
+var ErrTaskTimeout = errors.New("task timed out")
+
+// ErrWorkerBounds is returned when resizing the pool outside of
+// its minimum and maximum number of workers
+var ErrWorkerBounds = errors.New("number of workers out of bounds")
+
+// DefaultPriority is the priority of the tasks submitted with Submit
+const DefaultPriority = 0
+
 // WorkerPool provides a simple worker pool implementation,
-// allowing tasks to be executed concurrently with a fixed number of worker goroutines.
+// allowing tasks to be executed concurrently with a fixed number of worker goroutines,
+// or an autoscaling number with WithAutoscaling.
 // It supports graceful shutdowns and immediate termination of workers.
+// Workers always take the pending task with the highest priority,
+// tasks with the same priority are taken in submission order.
 type WorkerPool struct {
-	tasks      chan Task
+	// tasks holds one token per pending task in the queue,
+	// its buffer limits the number of pending tasks
+	tasks chan struct{}
+
+	// queueLock guards queue and seq, queueReady signals a worker
+	// holding a token that its task has been queued
+	queueLock  sync.Mutex
+	queueReady *sync.Cond
+	queue      collection.PriorityQueue[*prioritizedTask]
+	seq        uint64
+	started    time.Time
+	aging      time.Duration
+
+	name         string
+	panicHandler PanicHandler
+	taskTimeout  time.Duration
+	onTaskStart  func(TaskInfo)
+	onTaskEnd    func(TaskInfo)
+	minWorkers   int
+	maxWorkers   int
+	idleTimeout  time.Duration
+
+	// autoscaling is nil unless the pool autoscales, pending then holds
+	// the submission time of the pending tasks by sequence number
+	autoscaling *Autoscaling
+	onScale     func(ScaleEvent)
+	pending     *collection.OrderedMap[uint64, time.Time]
+	busy        atomic.Int32
+
 	wg         sync.WaitGroup
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 	isClosed   bool
-	lock       sync.RWMutex
-	numWorkers int
-	doneChan   chan struct{}
+	// closing is closed as soon as the pool starts closing,
+	// waking up the submissions waiting for space
+	closing     chan struct{}
+	closingOnce sync.Once
+	lock        sync.RWMutex
+	numWorkers  int
+	doneChan    chan struct{}
+
+	// taken is closed and replaced when a worker takes a token while
+	// submissions wait for space, waking them up to try again
+	taken   atomic.Pointer[chan struct{}]
+	waiters atomic.Int32
+}
+
+// prioritizedTask is a pending task in the queue
+type prioritizedTask struct {
+	task Task
+	// score is the priority minus the aging accumulated by the time the
+	// pool started, so that comparing scores never changes over time
+	score     float64
+	seq       uint64
+	priority  int
+	submitted time.Time
 }
 
 // NewWorkerPool creates a worker pool.
-func NewWorkerPool(ctx context.Context, numWorkers int, taskBuffer int) *WorkerPool {
+// The number of workers is kept within the minimum and maximum number
+// of workers of the options.
+func NewWorkerPool(
+	ctx context.Context,
+	numWorkers int,
+	taskBuffer int,
+	options ...WorkerPoolOption,
+) *WorkerPool {
+	settings := newWorkerPoolSettings(options)
 	ctx, cancel := context.WithCancel(ctx)
 
+	numWorkers = max(numWorkers, settings.minWorkers)
+	if settings.autoscaling != nil && settings.maxWorkers == 0 {
+		settings.maxWorkers = max(4*numWorkers, settings.minWorkers, 1)
+	}
+	if settings.maxWorkers > 0 {
+		numWorkers = min(numWorkers, settings.maxWorkers)
+	}
+
 	pool := &WorkerPool{
-		tasks:      make(chan Task, taskBuffer),
-		ctx:        ctx,
-		cancelFunc: cancel,
-		numWorkers: numWorkers,
-		doneChan:   make(chan struct{}, numWorkers),
+		tasks:        make(chan struct{}, taskBuffer),
+		queue:        collection.NewPriorityQueue(higherPriority),
+		started:      time.Now(),
+		aging:        settings.aging,
+		name:         settings.name,
+		panicHandler: settings.panicHandler,
+		taskTimeout:  settings.taskTimeout,
+		onTaskStart:  settings.onTaskStart,
+		onTaskEnd:    settings.onTaskEnd,
+		minWorkers:   settings.minWorkers,
+		maxWorkers:   settings.maxWorkers,
+		idleTimeout:  settings.idleTimeout,
+		autoscaling:  settings.autoscaling,
+		onScale:      settings.onScale,
+		ctx:          ctx,
+		cancelFunc:   cancel,
+		numWorkers:   numWorkers,
+		doneChan:     make(chan struct{}, max(numWorkers, settings.maxWorkers)),
+		closing:      make(chan struct{}),
+	}
+	pool.queueReady = sync.NewCond(&pool.queueLock)
+	taken := make(chan struct{})
+	pool.taken.Store(&taken)
+	if pool.panicHandler == nil {
+		pool.panicHandler = pool.logPanic
+	}
+	if pool.autoscaling != nil {
+		pool.pending = collection.NewOrderedMap[uint64, time.Time]()
+		go pool.autoscale()
 	}
 	pool.start(numWorkers)
 	return pool
 }
 
+// higherPriority orders the tasks by score then by submission
+func higherPriority(a, b *prioritizedTask) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	return a.seq < b.seq
+}
+
+type workerPoolSettings struct {
+	aging        time.Duration
+	name         string
+	panicHandler PanicHandler
+	taskTimeout  time.Duration
+	onTaskStart  func(TaskInfo)
+	onTaskEnd    func(TaskInfo)
+	minWorkers   int
+	maxWorkers   int
+	idleTimeout  time.Duration
+	autoscaling  *Autoscaling
+	onScale      func(ScaleEvent)
+}
+
+func newWorkerPoolSettings(options []WorkerPoolOption) *workerPoolSettings {
+	// Default settings
+	settings := &workerPoolSettings{}
+	for _, option := range options {
+		option.Apply(settings)
+	}
+	return settings
+}
+
+type WorkerPoolOption interface {
+	Apply(*workerPoolSettings)
+}
+
+// WithPriorityAging raises the priority of a pending task by one
+// for every interval it waits, so that a steady flow of high priority
+// tasks cannot starve the low priority ones.
+// Tasks do not age by default.
+func WithPriorityAging(interval time.Duration) WorkerPoolOption {
+	return withPriorityAging(interval)
+}
+
+type withPriorityAging time.Duration
+
+func (w withPriorityAging) Apply(settings *workerPoolSettings) {
+	settings.aging = time.Duration(w)
+}
+
+// WithPanicHandler routes the panics recovered from the tasks to the
+// handler. The panics are logged by default.
+func WithPanicHandler(handler PanicHandler) WorkerPoolOption {
+	return withPanicHandler(handler)
+}
+
+type withPanicHandler PanicHandler
+
+func (w withPanicHandler) Apply(settings *workerPoolSettings) {
+	settings.panicHandler = PanicHandler(w)
+}
+
+// WithName names the pool in the logs and in the TaskInfo of the hooks
+func WithName(name string) WorkerPoolOption {
+	return withName(name)
+}
+
+type withName string
+
+func (w withName) Apply(settings *workerPoolSettings) {
+	settings.name = string(w)
+}
+
+// WithTaskTimeout bounds the time given to each task. The context of the
+// functions submitted with SubmitFunc is canceled with ErrTaskTimeout once
+// the timeout elapses. Other tasks cannot be interrupted, the ones running
+// longer than the timeout are reported to the OnTaskEnd hook.
+// Tasks have no timeout by default.
+func WithTaskTimeout(timeout time.Duration) WorkerPoolOption {
+	return withTaskTimeout(timeout)
+}
+
+type withTaskTimeout time.Duration
+
+func (w withTaskTimeout) Apply(settings *workerPoolSettings) {
+	settings.taskTimeout = time.Duration(w)
+}
+
+// WithOnTaskStart calls the hook on the worker right before each task runs.
+// A panic of the hook is handled like a panic of the task, which then
+// does not run.
+func WithOnTaskStart(hook func(TaskInfo)) WorkerPoolOption {
+	return withOnTaskStart(hook)
+}
+
+type withOnTaskStart func(TaskInfo)
+
+func (w withOnTaskStart) Apply(settings *workerPoolSettings) {
+	settings.onTaskStart = w
+}
+
+// WithOnTaskEnd calls the hook on the worker right after each task ends,
+// including the tasks which panicked
+func WithOnTaskEnd(hook func(TaskInfo)) WorkerPoolOption {
+	return withOnTaskEnd(hook)
+}
+
+type withOnTaskEnd func(TaskInfo)
+
+func (w withOnTaskEnd) Apply(settings *workerPoolSettings) {
+	settings.onTaskEnd = w
+}
+
+// WithMinWorkers sets the minimum number of workers.
+// Defaults to zero.
+func WithMinWorkers(count int) WorkerPoolOption {
+	return withMinWorkers(count)
+}
+
+type withMinWorkers int
+
+func (w withMinWorkers) Apply(settings *workerPoolSettings) {
+	settings.minWorkers = max(int(w), 0)
+}
+
+// WithMaxWorkers sets the maximum number of workers.
+// The number of workers is unbounded by default, except for an
+// autoscaling pool which grows up to four times its initial size.
+func WithMaxWorkers(count int) WorkerPoolOption {
+	return withMaxWorkers(count)
+}
+
+type withMaxWorkers int
+
+func (w withMaxWorkers) Apply(settings *workerPoolSettings) {
+	settings.maxWorkers = int(w)
+}
+
+// WithIdleTimeout stops a worker which has been waiting for a task for
+// the timeout, as long as more than the minimum number of workers remain
+// and at least one worker is left to run the next task.
+// An autoscaling pool stops its idle workers itself once it has had idle
+// workers for the timeout.
+// Workers never stop when idle by default.
+func WithIdleTimeout(timeout time.Duration) WorkerPoolOption {
+	return withIdleTimeout(timeout)
+}
+
+type withIdleTimeout time.Duration
+
+func (w withIdleTimeout) Apply(settings *workerPoolSettings) {
+	settings.idleTimeout = time.Duration(w)
+}
+
+// Name returns the name of the pool
+func (p *WorkerPool) Name() string {
+	return p.name
+}
+
+// logPanic is the default PanicHandler, logging the panic and its stack
+func (p *WorkerPool) logPanic(err *PanicError) {
+	if p.name != "" {
+		log.Printf("sync: worker pool %q %v\n%s", p.name, err, err.Stack)
+		return
+	}
+	log.Printf("sync: worker pool %v\n%s", err, err.Stack)
+}
+
 // start kicks off the fixed number of worker goroutines.
 func (p *WorkerPool) start(numWorkers int) {
 	for i := 0; i < numWorkers; i++ {
@@ -45,54 +363,318 @@ func (p *WorkerPool) start(numWorkers int) {
 }
 
 func (p *WorkerPool) worker() {
+	// idle never fires without an idle timeout
+	var idle *time.Timer
+	var idleChan <-chan time.Time
+	if p.idleTimeout > 0 && p.autoscaling == nil {
+		idle = time.NewTimer(p.idleTimeout)
+		defer idle.Stop()
+		idleChan = idle.C
+	}
+
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
 		case <-p.doneChan:
 			return
-		case task, ok := <-p.tasks:
+		case <-idleChan:
+			if p.retire() {
+				return
+			}
+		case _, ok := <-p.tasks:
 			if !ok {
 				return
 			}
-			defer p.wg.Done()
-			task()
+			if p.waiters.Load() > 0 {
+				p.notifyTaken()
+			}
+			p.run(p.next())
 		}
+
+		if idle != nil {
+			idle.Reset(p.idleTimeout)
+		}
+	}
+}
+
+// retire removes an idle worker from the pool
+// true if the worker should stop, false if the pool is at its minimum
+// or this is the last worker
+func (p *WorkerPool) retire() bool {
+	// a worker must not wait for the lock: Resize holds it
+	// while waiting for the workers to take from doneChan
+	if !p.lock.TryLock() {
+		return false
 	}
+	defer p.lock.Unlock()
+
+	// the last worker stays, otherwise the submitted tasks would never run
+	if p.isClosed || p.numWorkers <= max(p.minWorkers, 1) {
+		return false
+	}
+	p.numWorkers--
+	return true
 }
 
-// Submit submits a task into the pool
-// If the task queue is full, Submit returns an error instead of blocking.
-// Client can retry some time later or report an error.
-// If the pool is closed, Submit returns an error.
+// run runs the task, recovering its panic so that the worker survives
+func (p *WorkerPool) run(next *prioritizedTask) {
+	defer p.wg.Done()
+	p.busy.Add(1)
+	defer p.busy.Add(-1)
+
+	info := TaskInfo{
+		Pool:      p.name,
+		Priority:  next.priority,
+		Submitted: next.submitted,
+		Started:   time.Now(),
+	}
+
+	defer func() {
+		info.Duration = time.Since(info.Started)
+		if r := recover(); r != nil {
+			err := newPanicError(r)
+			info.Err = err
+			p.panicHandler(err)
+		} else if p.taskTimeout > 0 && info.Duration > p.taskTimeout {
+			info.Err = ErrTaskTimeout
+		}
+
+		if p.onTaskEnd != nil {
+			p.onTaskEnd(info)
+		}
+	}()
+	if p.onTaskStart != nil {
+		p.onTaskStart(info)
+	}
+	next.task()
+}
+
+// next waits for the task matching a token taken from the tasks channel
+// and removes the pending task with the highest priority
+func (p *WorkerPool) next() *prioritizedTask {
+	p.queueLock.Lock()
+	defer p.queueLock.Unlock()
+
+	// the token is sent right before its task is queued
+	for p.queue.Empty() {
+		p.queueReady.Wait()
+	}
+	next, _ := p.queue.Pop()
+	if p.pending != nil {
+		p.pending.Delete(next.seq)
+	}
+	return next
+}
+
+// Submit submits a task into the pool with the default priority
+// If the task queue is full, Submit returns ErrQueueFull instead of blocking.
+// Client can retry some time later, use SubmitWait or report an error.
+// If the pool is closed, Submit returns ErrPoolClosed.
 func (p *WorkerPool) Submit(task Task) error {
-	if p.IsClosed() {
-		return errors.New("worker pool has been closed")
+	return p.SubmitWithPriority(task, DefaultPriority)
+}
+
+// SubmitWithPriority submits a task into the pool which runs before
+// the pending tasks with a lower priority.
+// It fails like Submit when the task queue is full or the pool is closed.
+func (p *WorkerPool) SubmitWithPriority(task Task, priority int) error {
+	return p.submit(context.Background(), task, priority, false)
+}
+
+// SubmitWait submits a task into the pool with the default priority,
+// waiting for space in the task queue.
+// ErrPoolClosed if the pool is closed or its context is canceled before
+// the task is submitted, the error of the context if it ends first.
+func (p *WorkerPool) SubmitWait(ctx context.Context, task Task) error {
+	return p.submit(ctx, task, DefaultPriority, true)
+}
+
+// TrySubmitTimeout submits a task into the pool with the default priority,
+// waiting up to the timeout for space in the task queue.
+// ErrQueueFull if there is still no space after the timeout,
+// ErrPoolClosed if the pool is closed.
+func (p *WorkerPool) TrySubmitTimeout(task Task, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := p.submit(ctx, task, DefaultPriority, true)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrQueueFull
+	}
+	return err
+}
+
+// submit submits the task, waiting for space until the context ends
+// if wait is true
+func (p *WorkerPool) submit(ctx context.Context, task Task, priority int, wait bool) error {
+	if wait {
+		p.waiters.Add(1)
+		defer p.waiters.Add(-1)
+	}
+
+	for {
+		// the channel must be loaded before trying, otherwise a token
+		// taken between the attempt and the wait could be missed
+		taken := p.taken.Load()
+
+		submitted, err := p.trySubmit(task, priority)
+		if err != nil || submitted {
+			return err
+		}
+		if !wait {
+			return ErrQueueFull
+		}
+
+		// the lock is not held while waiting, so that Resize and
+		// the other submissions can proceed
+		select {
+		case <-*taken:
+		case <-p.closing:
+			return ErrPoolClosed
+		case <-p.ctx.Done():
+			return ErrPoolClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// trySubmit submits the task if there is space in the task queue
+// ErrPoolClosed if the pool is closed
+func (p *WorkerPool) trySubmit(task Task, priority int) (bool, error) {
+	// holding the read lock prevents closing the tasks channel
+	// between the check and the send
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.isClosed {
+		return false, ErrPoolClosed
 	}
 
 	p.wg.Add(1)
 	select {
-	case p.tasks <- task:
-		return nil
+	case p.tasks <- struct{}{}:
+		p.enqueue(task, priority)
+		return true, nil
 	default:
-		// If the channel is full, return an error.
 		// Manually call Done if we can't add to the channel.
 		p.wg.Done()
-		return errors.New("task queue is full")
+		return false, nil
+	}
+}
+
+// notifyTaken wakes up the submissions waiting for space
+func (p *WorkerPool) notifyTaken() {
+	next := make(chan struct{})
+	// every channel is swapped out exactly once, so it is closed exactly once
+	previous := p.taken.Swap(&next)
+	close(*previous)
+}
+
+// SubmitFunc submits a function into the pool with the default priority
+// and returns a future of its result. The function receives the context
+// of the pool, bounded by the task timeout if any. The future settles with
+// the result and the error of the function, a *PanicError if the function
+// panics instead of calling the PanicHandler, ErrTaskTimeout once the task
+// timeout elapses, or the error of the context of the pool once it is
+// canceled, e.g. by CloseImmediately, even if the function has not run yet.
+// It fails like Submit when the task queue is full or the pool is closed.
+func SubmitFunc[T any](p *WorkerPool, fn func(ctx context.Context) (T, error)) (*Future[T], error) {
+	var zero T
+	f := newFuture[T]()
+	stop := context.AfterFunc(p.ctx, func() {
+		f.complete(zero, p.ctx.Err())
+	})
+
+	err := p.Submit(func() {
+		defer stop()
+		defer func() {
+			if r := recover(); r != nil {
+				f.complete(zero, newPanicError(r))
+			}
+		}()
+
+		ctx, cancel := p.taskContext()
+		defer cancel()
+		stopTask := context.AfterFunc(ctx, func() {
+			f.complete(zero, context.Cause(ctx))
+		})
+		defer stopTask()
+
+		if ctx.Err() != nil {
+			// the future has settled with the error of the context
+			return
+		}
+		f.complete(fn(ctx))
+	})
+	if err != nil {
+		stop()
+		return nil, err
 	}
+	return f, nil
 }
 
+// taskContext returns the context of a task, which is canceled with
+// ErrTaskTimeout once the task timeout elapses
+func (p *WorkerPool) taskContext() (context.Context, context.CancelFunc) {
+	if p.taskTimeout > 0 {
+		return context.WithTimeoutCause(p.ctx, p.taskTimeout, ErrTaskTimeout)
+	}
+	return context.WithCancel(p.ctx)
+}
+
+// enqueue adds the task to the queue and wakes up a worker
+func (p *WorkerPool) enqueue(task Task, priority int) {
+	p.queueLock.Lock()
+	defer p.queueLock.Unlock()
+
+	score := float64(priority)
+	if p.aging > 0 {
+		// a task submitted later has aged less, the difference
+		// between two tasks stays the same while both wait
+		score -= float64(time.Since(p.started)) / float64(p.aging)
+	}
+	p.seq++
+	submitted := time.Now()
+	p.queue.Push(&prioritizedTask{
+		task:      task,
+		score:     score,
+		seq:       p.seq,
+		priority:  priority,
+		submitted: submitted,
+	})
+	if p.pending != nil {
+		p.pending.Set(p.seq, submitted)
+	}
+	p.queueReady.Signal()
+}
+
+// Resize changes the number of workers
+// ErrWorkerBounds if the number is below the minimum or above the maximum
+// number of workers
 func (p *WorkerPool) Resize(numWorkers int) error {
 	if p.IsClosed() {
-		return errors.New("worker pool has been closed")
+		return ErrPoolClosed
+	}
+
+	if numWorkers < p.minWorkers || (p.maxWorkers > 0 && numWorkers > p.maxWorkers) {
+		return fmt.Errorf("%w: %d workers with minimum %d and maximum %d",
+			ErrWorkerBounds, numWorkers, p.minWorkers, p.maxWorkers)
 	}
 
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	if p.isClosed {
-		return errors.New("worker pool has been closed")
+		return ErrPoolClosed
 	}
 
+	p.resize(numWorkers)
+	return nil
+}
+
+// resize starts or stops workers to reach the number of workers
+// resize must be called with the lock held
+func (p *WorkerPool) resize(numWorkers int) {
 	if p.numWorkers < numWorkers {
 		// increase worker go-routines
 		for i := 0; i < (numWorkers - p.numWorkers); i++ {
@@ -106,7 +688,6 @@ func (p *WorkerPool) Resize(numWorkers int) error {
 	}
 
 	p.numWorkers = numWorkers
-	return nil
 }
 
 func (p *WorkerPool) Workers() int {
@@ -146,6 +727,9 @@ func (p *WorkerPool) close() bool {
 		return false
 	}
 
+	p.closingOnce.Do(func() {
+		close(p.closing)
+	})
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -211,3 +212,553 @@ func TestWorkerPool_Resize(t *testing.T) {
 		require.ErrorContains(t, err, "worker pool has been closed")
 	})
 }
+
+func TestWorkerPool_SubmitWait(t *testing.T) {
+	// newBlockedPool creates a pool whose only worker and task queue
+	// are taken until release is closed
+	newBlockedPool := func(t *testing.T) (*WorkerPool, chan struct{}) {
+		pool := NewWorkerPool(context.Background(), 1, 1)
+		release := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { <-release }))
+		require.EventuallyWithT(t,
+			func(c *assert.CollectT) {
+				require.ErrorIs(c, pool.Submit(func() { <-release }), ErrQueueFull)
+			},
+			500*time.Millisecond,
+			1*time.Millisecond,
+		)
+		return pool, release
+	}
+
+	t.Run("SubmitWait should wait for space", func(t *testing.T) {
+		t.Parallel()
+		pool, release := newBlockedPool(t)
+		time.AfterFunc(20*time.Millisecond, func() { close(release) })
+
+		done := make(chan struct{})
+		require.NoError(t, pool.SubmitWait(context.Background(), func() { close(done) }))
+		pool.Close()
+		select {
+		case <-done:
+		default:
+			require.Fail(t, "task submitted with SubmitWait should run before close")
+		}
+	})
+
+	t.Run("SubmitWait should return the error of the context", func(t *testing.T) {
+		t.Parallel()
+		pool, release := newBlockedPool(t)
+		defer pool.Close()
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := pool.SubmitWait(ctx, func() {})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("SubmitWait should return when the pool closes", func(t *testing.T) {
+		t.Parallel()
+		pool, release := newBlockedPool(t)
+		defer close(release)
+
+		errs := make(chan error)
+		go func() {
+			errs <- pool.SubmitWait(context.Background(), func() {})
+		}()
+		time.Sleep(10 * time.Millisecond)
+		pool.CloseImmediately()
+
+		select {
+		case err := <-errs:
+			require.ErrorIs(t, err, ErrPoolClosed)
+		case <-time.After(time.Second):
+			require.Fail(t, "SubmitWait did not return after close")
+		}
+		require.ErrorIs(t, pool.SubmitWait(context.Background(), func() {}), ErrPoolClosed)
+	})
+
+	t.Run("SubmitWait should not block Resize and the other submissions", func(t *testing.T) {
+		t.Parallel()
+		pool, release := newBlockedPool(t)
+		defer pool.Close()
+		defer close(release)
+
+		waited := make(chan error, 1)
+		go func() {
+			waited <- pool.SubmitWait(context.Background(), func() { <-release })
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		resized := make(chan error, 1)
+		go func() {
+			resized <- pool.Resize(2)
+		}()
+		submitted := make(chan error, 1)
+		go func() {
+			submitted <- pool.Submit(func() {})
+			_ = pool.Workers()
+			_ = pool.IsClosed()
+		}()
+
+		for _, result := range []chan error{resized, waited} {
+			select {
+			case err := <-result:
+				require.NoError(t, err)
+			case <-time.After(time.Second):
+				require.Fail(t, "Resize and SubmitWait should not block each other")
+			}
+		}
+		select {
+		case err := <-submitted:
+			if err != nil {
+				require.ErrorIs(t, err, ErrQueueFull)
+			}
+		case <-time.After(time.Second):
+			require.Fail(t, "Submit should not block behind a waiting submission")
+		}
+		require.Equal(t, 2, pool.Workers())
+	})
+
+	t.Run("TrySubmitTimeout should return ErrQueueFull after the timeout", func(t *testing.T) {
+		t.Parallel()
+		pool, release := newBlockedPool(t)
+		defer pool.Close()
+
+		start := time.Now()
+		err := pool.TrySubmitTimeout(func() {}, 20*time.Millisecond)
+		require.ErrorIs(t, err, ErrQueueFull)
+		require.ErrorContains(t, err, "task queue is full")
+		require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+		close(release)
+		require.NoError(t, pool.TrySubmitTimeout(func() {}, time.Second))
+	})
+
+	t.Run("sentinel errors should keep their messages", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 1)
+		pool.Close()
+
+		err := pool.TrySubmitTimeout(func() {}, time.Second)
+		require.ErrorIs(t, err, ErrPoolClosed)
+		require.EqualError(t, err, "worker pool has been closed")
+		require.ErrorIs(t, pool.Resize(2), ErrPoolClosed)
+	})
+}
+
+func TestWorkerPool_Panic(t *testing.T) {
+	// newPool creates a pool counting the panics it recovers
+	newPool := func(numWorkers int, taskBuffer int) (*WorkerPool, *atomic.Int32) {
+		panics := &atomic.Int32{}
+		pool := NewWorkerPool(context.Background(), numWorkers, taskBuffer, WithPanicHandler(func(err *PanicError) {
+			panics.Add(1)
+		}))
+		return pool, panics
+	}
+
+	t.Run("panicking task should be routed to the handler", func(t *testing.T) {
+		t.Parallel()
+		recovered := make(chan *PanicError, 1)
+		pool := NewWorkerPool(context.Background(), 1, 5, WithPanicHandler(func(err *PanicError) {
+			recovered <- err
+		}))
+		defer pool.Close()
+
+		require.NoError(t, pool.Submit(func() { panic("boom") }))
+		select {
+		case err := <-recovered:
+			require.Equal(t, "boom", err.Value)
+			require.ErrorContains(t, err, "task panicked: boom")
+			require.NotEmpty(t, err.Stack)
+		case <-time.After(time.Second):
+			require.Fail(t, "panic was not handled in time")
+		}
+
+		done := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { close(done) }))
+		select {
+		case <-done:
+			// the worker survived the panic
+		case <-time.After(time.Second):
+			require.Fail(t, "worker did not survive the panic")
+		}
+	})
+
+	t.Run("Close should wait for every task including panicking ones", func(t *testing.T) {
+		t.Parallel()
+		counter := 20
+		pool, panics := newPool(2, counter)
+		var executed int32
+
+		for i := 0; i < counter; i++ {
+			index := i
+			err := pool.Submit(func() {
+				time.Sleep(time.Millisecond)
+				if index%2 == 0 {
+					panic(index)
+				}
+				atomic.AddInt32(&executed, 1)
+			})
+			require.NoError(t, err)
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			pool.Close()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			require.Fail(t, "Close did not return in time")
+		}
+		require.Equal(t, int32(counter/2), atomic.LoadInt32(&executed), "all tasks should execute before close")
+		require.Equal(t, int32(counter/2), panics.Load(), "every panic should be handled before close")
+	})
+
+	t.Run("Resize should keep working with panicking tasks", func(t *testing.T) {
+		t.Parallel()
+		counter := 30
+		pool, panics := newPool(5, counter)
+
+		wg := &sync.WaitGroup{}
+		wg.Add(counter)
+		for i := 0; i < counter; i++ {
+			err := pool.Submit(func() {
+				defer wg.Done()
+				time.Sleep(time.Millisecond)
+				panic("boom")
+			})
+			require.NoError(t, err)
+		}
+
+		for _, size := range []int{1, 8, 3} {
+			require.NoError(t, pool.Resize(size))
+			require.Equal(t, size, pool.Workers())
+		}
+		wg.Wait()
+
+		done := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { close(done) }))
+		<-done
+		pool.Close()
+		require.Equal(t, int32(counter), panics.Load())
+	})
+}
+
+func TestWorkerPool_SubmitWithPriority(t *testing.T) {
+	// runInOrder blocks the only worker while the tasks are submitted,
+	// then returns the order in which they ran
+	runInOrder := func(t *testing.T, pool *WorkerPool, submit func(record func(int) Task)) []int {
+		release := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { <-release }))
+		// wait for the worker to take the blocking task
+		time.Sleep(10 * time.Millisecond)
+
+		lock := sync.Mutex{}
+		order := []int{}
+		submit(func(id int) Task {
+			return func() {
+				lock.Lock()
+				defer lock.Unlock()
+				order = append(order, id)
+			}
+		})
+
+		close(release)
+		pool.Close()
+		return order
+	}
+
+	t.Run("higher priority tasks should run first", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 10)
+		order := runInOrder(t, pool, func(record func(int) Task) {
+			for _, priority := range []int{1, -5, 10, DefaultPriority, 3} {
+				require.NoError(t, pool.SubmitWithPriority(record(priority), priority))
+			}
+		})
+		require.Equal(t, []int{10, 3, 1, DefaultPriority, -5}, order)
+	})
+
+	t.Run("tasks with the same priority should run in submission order", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 10)
+		order := runInOrder(t, pool, func(record func(int) Task) {
+			for i := 0; i < 5; i++ {
+				require.NoError(t, pool.Submit(record(i)))
+			}
+			require.NoError(t, pool.SubmitWithPriority(record(5), 1))
+		})
+		require.Equal(t, []int{5, 0, 1, 2, 3, 4}, order)
+	})
+
+	t.Run("aging should prevent starvation", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 10, WithPriorityAging(time.Millisecond))
+		order := runInOrder(t, pool, func(record func(int) Task) {
+			require.NoError(t, pool.SubmitWithPriority(record(0), 0))
+			// the first task gains about 50 levels while waiting
+			time.Sleep(50 * time.Millisecond)
+			require.NoError(t, pool.SubmitWithPriority(record(10), 10))
+			require.NoError(t, pool.SubmitWithPriority(record(1000), 1000))
+		})
+		require.Equal(t, []int{1000, 0, 10}, order)
+	})
+
+	t.Run("full queue should return an error", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 1)
+		release := make(chan struct{})
+		defer pool.Close()
+		defer close(release)
+
+		require.NoError(t, pool.Submit(func() { <-release }))
+		require.EventuallyWithT(t,
+			func(c *assert.CollectT) {
+				err := pool.SubmitWithPriority(func() {}, 100)
+				require.ErrorContains(c, err, "task queue is full")
+			},
+			500*time.Millisecond,
+			1*time.Millisecond,
+		)
+	})
+}
+
+func TestSubmitFunc(t *testing.T) {
+	t.Run("future should settle with the result", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 2, 5)
+		defer pool.Close()
+
+		f, err := SubmitFunc(pool, func(ctx context.Context) (int, error) {
+			return 42, nil
+		})
+		require.NoError(t, err)
+		result, err := f.Get()
+		require.NoError(t, err)
+		require.Equal(t, 42, result)
+	})
+
+	t.Run("future should settle with the error", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 2, 5)
+		defer pool.Close()
+
+		f, err := SubmitFunc(pool, func(ctx context.Context) (int, error) {
+			return 0, errors.New("something went wrong")
+		})
+		require.NoError(t, err)
+		_, err = f.Get()
+		require.ErrorContains(t, err, "something went wrong")
+	})
+
+	t.Run("future should settle with a recovered panic", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 5)
+		defer pool.Close()
+
+		f, err := SubmitFunc(pool, func(ctx context.Context) (string, error) {
+			panic("boom")
+		})
+		require.NoError(t, err)
+		_, err = f.Get()
+		panicErr := &PanicError{}
+		require.ErrorAs(t, err, &panicErr)
+		require.Equal(t, "boom", panicErr.Value)
+		require.NotEmpty(t, panicErr.Stack)
+
+		// the worker should survive the panic
+		f2, err := SubmitFunc(pool, func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		require.NoError(t, err)
+		result, err := f2.Get()
+		require.NoError(t, err)
+		require.Equal(t, 1, result)
+	})
+
+	t.Run("pending future should settle when the pool is canceled", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 5)
+		release := make(chan struct{})
+		defer close(release)
+		require.NoError(t, pool.Submit(func() { <-release }))
+
+		ran := atomic.Bool{}
+		f, err := SubmitFunc(pool, func(ctx context.Context) (int, error) {
+			ran.Store(true)
+			return 1, nil
+		})
+		require.NoError(t, err)
+
+		pool.CloseImmediately()
+		_, err = f.Get()
+		require.ErrorIs(t, err, context.Canceled)
+		require.False(t, ran.Load(), "the function should not run once canceled")
+	})
+
+	t.Run("submit after close should return an error", func(t *testing.T) {
+		pool := NewWorkerPool(context.Background(), 1, 5)
+		pool.Close()
+
+		f, err := SubmitFunc(pool, func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		require.ErrorContains(t, err, "worker pool has been closed")
+		require.Nil(t, f)
+	})
+}
+
+func TestWorkerPool_Options(t *testing.T) {
+	t.Run("hooks should observe every task", func(t *testing.T) {
+		t.Parallel()
+		lock := sync.Mutex{}
+		started, ended := []TaskInfo{}, []TaskInfo{}
+		pool := NewWorkerPool(context.Background(), 1, 5,
+			WithName("hooks"),
+			WithTaskTimeout(20*time.Millisecond),
+			WithPanicHandler(func(*PanicError) {}),
+			WithOnTaskStart(func(info TaskInfo) {
+				lock.Lock()
+				defer lock.Unlock()
+				started = append(started, info)
+			}),
+			WithOnTaskEnd(func(info TaskInfo) {
+				lock.Lock()
+				defer lock.Unlock()
+				ended = append(ended, info)
+			}),
+		)
+		require.Equal(t, "hooks", pool.Name())
+
+		require.NoError(t, pool.SubmitWithPriority(func() {}, 3))
+		require.NoError(t, pool.Submit(func() { panic("boom") }))
+		require.NoError(t, pool.Submit(func() { time.Sleep(40 * time.Millisecond) }))
+		pool.Close()
+
+		require.Len(t, started, 3)
+		require.Len(t, ended, 3)
+		for _, info := range ended {
+			require.Equal(t, "hooks", info.Pool)
+			require.False(t, info.Started.Before(info.Submitted))
+		}
+		require.Equal(t, 3, ended[0].Priority)
+		require.NoError(t, ended[0].Err)
+		panicErr := &PanicError{}
+		require.ErrorAs(t, ended[1].Err, &panicErr, "a panicking task should end with its panic")
+		require.ErrorIs(t, ended[2].Err, ErrTaskTimeout, "a slow task should end with a timeout")
+		require.GreaterOrEqual(t, ended[2].Duration, 40*time.Millisecond)
+	})
+
+	t.Run("a panicking start hook should be handled like a panicking task", func(t *testing.T) {
+		t.Parallel()
+		panics := make(chan *PanicError, 1)
+		ended := make(chan TaskInfo, 1)
+		ran := false
+		pool := NewWorkerPool(context.Background(), 1, 5,
+			WithPanicHandler(func(err *PanicError) { panics <- err }),
+			WithOnTaskStart(func(TaskInfo) { panic("hook") }),
+			WithOnTaskEnd(func(info TaskInfo) { ended <- info }),
+		)
+
+		require.NoError(t, pool.Submit(func() { ran = true }))
+		pool.Close()
+
+		require.Equal(t, "hook", (<-panics).Value)
+		panicErr := &PanicError{}
+		require.ErrorAs(t, (<-ended).Err, &panicErr)
+		require.False(t, ran, "the task should not run after its start hook panics")
+	})
+
+	t.Run("task timeout should cancel the context of SubmitFunc", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 1, 5, WithTaskTimeout(10*time.Millisecond))
+		defer pool.Close()
+
+		f, err := SubmitFunc(pool, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, context.Cause(ctx)
+		})
+		require.NoError(t, err)
+		_, err = f.Get()
+		require.ErrorIs(t, err, ErrTaskTimeout)
+	})
+
+	t.Run("number of workers should stay within the bounds", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 10, 5, WithMinWorkers(2), WithMaxWorkers(4))
+		defer pool.Close()
+		require.Equal(t, 4, pool.Workers(), "NewWorkerPool should clamp to the maximum")
+
+		require.ErrorIs(t, pool.Resize(5), ErrWorkerBounds)
+		require.ErrorIs(t, pool.Resize(1), ErrWorkerBounds)
+		require.NoError(t, pool.Resize(2))
+		require.Equal(t, 2, pool.Workers())
+	})
+
+	t.Run("number of workers should be unbounded by default", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 0, 5)
+		defer pool.Close()
+		require.Equal(t, 0, pool.Workers())
+
+		require.NoError(t, pool.Resize(20))
+		require.Equal(t, 20, pool.Workers())
+		require.NoError(t, pool.Resize(0))
+		require.Equal(t, 0, pool.Workers())
+		require.ErrorIs(t, pool.Resize(-1), ErrWorkerBounds)
+	})
+
+	t.Run("idle workers should stop down to the minimum", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 4, 5, WithMinWorkers(2), WithIdleTimeout(10*time.Millisecond))
+		defer pool.Close()
+
+		require.Eventually(t, func() bool {
+			return pool.Workers() == 2
+		}, time.Second, time.Millisecond)
+
+		// the remaining workers should keep running tasks
+		time.Sleep(30 * time.Millisecond)
+		require.Equal(t, 2, pool.Workers())
+		done := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { close(done) }))
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			require.Fail(t, "task did not execute in time")
+		}
+	})
+
+	t.Run("the last idle worker should keep running tasks", func(t *testing.T) {
+		t.Parallel()
+		pool := NewWorkerPool(context.Background(), 2, 5, WithIdleTimeout(10*time.Millisecond))
+
+		require.Eventually(t, func() bool {
+			return pool.Workers() == 1
+		}, time.Second, time.Millisecond)
+		time.Sleep(30 * time.Millisecond)
+		require.Equal(t, 1, pool.Workers())
+
+		done := make(chan struct{})
+		require.NoError(t, pool.Submit(func() { close(done) }))
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			require.Fail(t, "task did not execute in time")
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			pool.Close()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			require.Fail(t, "Close did not return in time")
+		}
+	})
+}